@@ -0,0 +1,311 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+var testCredentials = Credentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+const testRegion = "eu-west-1"
+
+// standIn is a local stand-in for Amazon Translate that rejects requests
+// with invalid signatures and dispatches valid ones by operation.
+type standIn struct {
+	t          *testing.T
+	operations map[string]func(w http.ResponseWriter, body []byte)
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.t.Fatalf("Unexpected error reading request body: %s", err)
+	}
+
+	if r.Method != "POST" {
+		s.t.Fatalf("Unexpected request method: %s", r.Method)
+	}
+
+	if r.Header.Get("Content-Type") != contentType {
+		s.t.Fatalf("Unexpected content type: %s", r.Header.Get("Content-Type"))
+	}
+
+	if !s.validSignature(r, body) {
+		w.Header().Set(errorTypeHeader, "InvalidSignatureException:http://internal.amazon.com/coral/com.amazon.coral.service/")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"The request signature we calculated does not match the signature you provided."}`)
+		return
+	}
+
+	operation, ok := s.operations[r.Header.Get(targetHeader)]
+	if !ok {
+		s.t.Fatalf("Unexpected operation: %s", r.Header.Get(targetHeader))
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	operation(w, body)
+}
+
+// validSignature signs a copy of the received request with the expected
+// credentials at the time given by its X-Amz-Date header and compares the
+// resulting Authorization header with the received one.
+func (s *standIn) validSignature(r *http.Request, body []byte) bool {
+	date, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	expected, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		s.t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, header := range []string{"Content-Type", targetHeader} {
+		expected.Header.Set(header, r.Header.Get(header))
+	}
+
+	signer := NewSigner(testCredentials, testRegion, service).(*signer)
+	signer.now = func() time.Time { return date }
+
+	if err := signer.Authenticate(expected); err != nil {
+		s.t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return expected.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func newTestTranslator(server *httptest.Server, credentials Credentials) translator.Translator {
+	return NewTranslator(
+		testRegion,
+		credentials,
+		WithEndpoint(server.URL),
+		WithHTTPClient(server.Client()),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+}
+
+func TestTranslate(t *testing.T) {
+	expectedRequest := translationRequest{
+		Text:               "Hello World!",
+		SourceLanguageCode: "en",
+		TargetLanguageCode: "de",
+	}
+
+	server := httptest.NewServer(&standIn{t, map[string]func(http.ResponseWriter, []byte){
+		translateTextTarget: func(w http.ResponseWriter, body []byte) {
+			actualRequest := translationRequest{}
+			if err := json.Unmarshal(body, &actualRequest); err != nil {
+				t.Fatalf("Unexpected error decoding request: %s", err)
+			}
+
+			if !reflect.DeepEqual(actualRequest, expectedRequest) {
+				t.Fatalf("Unexpected request. Got: %+v. Want: %+v.", actualRequest, expectedRequest)
+			}
+
+			fmt.Fprint(w, `{"SourceLanguageCode":"en","TargetLanguageCode":"de","TranslatedText":"Hallo Welt!"}`)
+		},
+	}})
+	defer server.Close()
+
+	actual, err := newTestTranslator(server, testCredentials).Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual != "Hallo Welt!" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", actual, "Hallo Welt!")
+	}
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	expectedRequest := translationRequest{
+		Text:               "Hello World!",
+		SourceLanguageCode: "en",
+		TargetLanguageCode: "de",
+		Settings:           &translationSettings{Profanity: "MASK"},
+		TerminologyNames:   []string{"my-terminology"},
+	}
+
+	server := httptest.NewServer(&standIn{t, map[string]func(http.ResponseWriter, []byte){
+		translateTextTarget: func(w http.ResponseWriter, body []byte) {
+			actualRequest := translationRequest{}
+			if err := json.Unmarshal(body, &actualRequest); err != nil {
+				t.Fatalf("Unexpected error decoding request: %s", err)
+			}
+
+			if !reflect.DeepEqual(actualRequest, expectedRequest) {
+				t.Fatalf("Unexpected request. Got: %+v. Want: %+v.", actualRequest, expectedRequest)
+			}
+
+			fmt.Fprint(w, `{"SourceLanguageCode":"en","TargetLanguageCode":"de","TranslatedText":"Hallo Welt!"}`)
+		},
+	}})
+	defer server.Close()
+
+	tr := newTestTranslator(server, testCredentials).(translator.OptionsTranslator)
+
+	result, err := tr.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text: "Hello World!",
+		From: "en",
+		To:   "de",
+		TranslateOptions: translator.TranslateOptions{
+			Profanity: translator.ProfanityMark,
+			Glossary:  "my-terminology",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if result.Text != "Hallo Welt!" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", result.Text, "Hallo Welt!")
+	}
+
+	for _, opts := range []translator.TranslateOptions{
+		{Profanity: translator.ProfanityDelete},
+		{Format: translator.HTML},
+	} {
+		_, err := tr.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+			Text:             "Hello World!",
+			From:             "en",
+			To:               "de",
+			TranslateOptions: opts,
+		})
+
+		if !errors.Is(err, translator.ErrUnsupportedOption) {
+			t.Fatalf("Expected ErrUnsupportedOption for %+v. Got: %v", opts, err)
+		}
+	}
+}
+
+func TestTranslateInvalidSignature(t *testing.T) {
+	server := httptest.NewServer(&standIn{t, nil})
+	defer server.Close()
+
+	credentials := testCredentials
+	credentials.SecretAccessKey = "wrong-secret"
+
+	_, err := newTestTranslator(server, credentials).Translate("Hello World!", "en", "de")
+	if !errors.Is(err, translator.ErrUnauthorized) {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, translator.ErrUnauthorized)
+	}
+}
+
+func TestTranslateRetriesThrottling(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(&standIn{t, map[string]func(http.ResponseWriter, []byte){
+		translateTextTarget: func(w http.ResponseWriter, body []byte) {
+			requestCounter++
+
+			if requestCounter == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"__type":"com.amazonaws.translate#TooManyRequestsException","message":"Rate exceeded"}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"SourceLanguageCode":"en","TargetLanguageCode":"de","TranslatedText":"Hallo Welt!"}`)
+		},
+	}})
+	defer server.Close()
+
+	_, err := newTestTranslator(server, testCredentials).Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(&standIn{t, map[string]func(http.ResponseWriter, []byte){
+		translateTextTarget: func(w http.ResponseWriter, body []byte) {
+			request := translationRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Fatalf("Unexpected error decoding request: %s", err)
+			}
+
+			if request.SourceLanguageCode != autoSource {
+				t.Fatalf("Unexpected source language. Got: %s. Want: %s.", request.SourceLanguageCode, autoSource)
+			}
+
+			fmt.Fprint(w, `{"SourceLanguageCode":"de","TargetLanguageCode":"en","TranslatedText":"Hello World!"}`)
+		},
+	}})
+	defer server.Close()
+
+	actual, err := newTestTranslator(server, testCredentials).Detect("Hallo Welt!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual != "de" {
+		t.Fatalf("Unexpected language. Got: %s. Want: %s.", actual, "de")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(&standIn{t, map[string]func(http.ResponseWriter, []byte){
+		listLanguagesTarget: func(w http.ResponseWriter, body []byte) {
+			requestCounter++
+
+			request := listLanguagesRequest{}
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Fatalf("Unexpected error decoding request: %s", err)
+			}
+
+			switch request.NextToken {
+			case "":
+				fmt.Fprint(w, `{"Languages":[{"LanguageCode":"de","LanguageName":"German"}],"NextToken":"page-2"}`)
+			case "page-2":
+				fmt.Fprint(w, `{"Languages":[{"LanguageCode":"en","LanguageName":"English"},{"LanguageCode":"zh-TW","LanguageName":"Chinese (Traditional)"}]}`)
+			default:
+				t.Fatalf("Unexpected next token: %s", request.NextToken)
+			}
+		},
+	}})
+	defer server.Close()
+
+	tr := newTestTranslator(server, testCredentials)
+
+	expected := []translator.Language{
+		{Code: "de", Name: "German", Tag: "de"},
+		{Code: "en", Name: "English", Tag: "en"},
+		{Code: "zh-TW", Name: "Chinese (Traditional)", Tag: "zh-Hant"},
+	}
+
+	for i := 0; i < 2; i++ {
+		actual, err := tr.Languages()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected languages. Got: %v. Want: %v.", actual, expected)
+		}
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
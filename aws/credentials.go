@@ -0,0 +1,23 @@
+package aws
+
+import "os"
+
+// Credentials are the AWS security credentials used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is only required for temporary credentials, e.g. those
+	// of an assumed role.
+	SessionToken string
+}
+
+// CredentialsFromEnv returns the credentials specified by the environment
+// variables AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
@@ -0,0 +1,12 @@
+package aws
+
+import "github.com/st3v/translator"
+
+// codeMap maps canonical BCP 47 tags to the language codes of Amazon Translate
+// where the two differ.
+var codeMap = translator.CodeMap{
+	"fil":     "tl",
+	"nb":      "no",
+	"zh-Hans": "zh",
+	"zh-Hant": "zh-TW",
+}
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// ListLanguages returns at most 500 languages per page.
+const maxLanguagesPerPage = 500
+
+type listLanguagesRequest struct {
+	DisplayLanguageCode string `json:",omitempty"`
+	MaxResults          int    `json:",omitempty"`
+	NextToken           string `json:",omitempty"`
+}
+
+type languagesPayload struct {
+	Languages []struct {
+		LanguageCode string
+		LanguageName string
+	}
+	NextToken string
+}
+
+type languageProvider interface {
+	languages(ctx context.Context) ([]translator.Language, error)
+	refresh(ctx context.Context) error
+}
+
+type concreteLanguageProvider struct {
+	router     *router
+	httpClient http.Client
+	catalog    *translator.Catalog
+}
+
+func newLanguageProvider(c http.Client, r *router, ttl time.Duration) *concreteLanguageProvider {
+	p := &concreteLanguageProvider{
+		router:     r,
+		httpClient: c,
+	}
+	p.catalog = translator.NewCatalog(ttl, p.fetchLanguages)
+	return p
+}
+
+func (p *concreteLanguageProvider) languages(ctx context.Context) ([]translator.Language, error) {
+	return p.catalog.Languages(ctx, "en")
+}
+
+func (p *concreteLanguageProvider) refresh(ctx context.Context) error {
+	return p.catalog.Refresh(ctx)
+}
+
+func (p *concreteLanguageProvider) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	languages := []translator.Language{}
+
+	request := &listLanguagesRequest{
+		DisplayLanguageCode: displayLocale,
+		MaxResults:          maxLanguagesPerPage,
+	}
+
+	for {
+		payload, err := p.fetch(ctx, request)
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		for _, l := range payload.Languages {
+			languages = append(languages, translator.Language{
+				Code: l.LanguageCode,
+				Name: l.LanguageName,
+				Tag:  codeMap.Tag(l.LanguageCode),
+			})
+		}
+
+		if payload.NextToken == "" {
+			break
+		}
+
+		request.NextToken = payload.NextToken
+	}
+
+	return languages, nil
+}
+
+func (p *concreteLanguageProvider) fetch(ctx context.Context, request *listLanguagesRequest) (*languagesPayload, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	resp, err := p.httpClient.SendRequest(ctx, "POST", p.router.url(), bytes.NewReader(body), contentType)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &languagesPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*languagesPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return payload, nil
+}
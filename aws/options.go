@@ -0,0 +1,114 @@
+package aws
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	endpoint      string
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	clientOptions []http.ClientOption
+}
+
+func newOptions(region string, credentials Credentials, opts []Option) *options {
+	o := &options{
+		endpoint:      endpointFor(region),
+		authenticator: NewSigner(credentials, region, service),
+		retryPolicy:   http.DefaultRetryPolicy(),
+		catalogTTL:    translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.retryPolicy.Retryable == nil {
+		o.retryPolicy.Retryable = retryable
+	}
+
+	return o
+}
+
+// httpClient returns a client for the given operation of Amazon Translate.
+func (o *options) httpClient(target string) http.Client {
+	clientOptions := append([]http.ClientOption{
+		http.WithRetryPolicy(o.retryPolicy),
+		http.WithHeader(targetHeader, target),
+	}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithEndpoint makes the Translator send requests to the given endpoint
+// instead of https://translate.{region}.amazonaws.com/, e.g. to use a
+// FIPS or VPC endpoint.
+func WithEndpoint(url string) Option {
+	return func(o *options) {
+		o.endpoint = url
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that signs every
+// request with the given credentials.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy. If the policy does not
+// specify a Retryable function, throttling errors are retried in addition
+// to the errors covered by http.Retryable.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const provider = "aws"
+
+const errorTypeHeader = "X-Amzn-ErrorType"
+
+type errorPayload struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+var parseResponse = func(resp *http.Response, target interface{}) (interface{}, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(resp, body)
+	}
+
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return target, nil
+}
+
+// newAPIError classifies the error described by the given response.
+// See https://docs.aws.amazon.com/translate/latest/APIReference/CommonErrors.html
+func newAPIError(resp *http.Response, body []byte) *translator.APIError {
+	err := _http.NewAPIError(provider, resp)
+
+	payload := &errorPayload{}
+	if json.Unmarshal(body, payload) == nil && payload.Message != "" {
+		err.Message = payload.Message
+	} else if len(body) > 0 {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	err.Code = errorType(resp, payload)
+
+	switch err.Code {
+	case "UnrecognizedClientException", "InvalidSignatureException", "IncompleteSignature",
+		"MissingAuthenticationToken", "AccessDeniedException", "ExpiredTokenException":
+		err.Err = translator.ErrUnauthorized
+	case "UnsupportedLanguagePairException", "UnsupportedDisplayLanguageCodeException":
+		err.Err = translator.ErrUnsupportedLanguage
+	case "TooManyRequestsException", "ThrottlingException":
+		err.Err = translator.ErrQuotaExceeded
+		err.Retryable = true
+	case "LimitExceededException", "ServiceQuotaExceededException":
+		err.Err = translator.ErrQuotaExceeded
+	}
+
+	return err
+}
+
+// errorType returns the unqualified type of the error, which is given by
+// the X-Amzn-ErrorType header or the __type field of the response body,
+// e.g. "TooManyRequestsException:http://internal.amazon.com/..." or
+// "com.amazonaws.translate#TooManyRequestsException".
+func errorType(resp *http.Response, payload *errorPayload) string {
+	errType := resp.Header.Get(errorTypeHeader)
+	if errType == "" {
+		errType = payload.Type
+	}
+
+	if i := strings.Index(errType, ":"); i >= 0 {
+		errType = errType[:i]
+	}
+
+	if i := strings.LastIndex(errType, "#"); i >= 0 {
+		errType = errType[i+1:]
+	}
+
+	return errType
+}
+
+// retryable extends http.Retryable by Amazon Translate's throttling errors,
+// which are reported with status 400 rather than 429. The response body is
+// restored so that it can still be parsed after the check.
+func retryable(resp *http.Response, err error) bool {
+	if _http.Retryable(resp, err) {
+		return true
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return newAPIError(resp, body).Retryable
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestParseResponseError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		header     string
+		body       string
+		code       string
+		retryable  bool
+		err        error
+	}{
+		{400, "", `{"__type":"com.amazonaws.translate#UnsupportedLanguagePairException","message":"Unsupported language pair: en to xx"}`, "UnsupportedLanguagePairException", false, translator.ErrUnsupportedLanguage},
+		{400, "UnrecognizedClientException:http://internal.amazon.com/coral/com.amazon.coral.service/", `{"message":"The security token included in the request is invalid."}`, "UnrecognizedClientException", false, translator.ErrUnauthorized},
+		{400, "", `{"__type":"ThrottlingException","message":"Rate exceeded"}`, "ThrottlingException", true, translator.ErrQuotaExceeded},
+		{400, "", `{"__type":"LimitExceededException","message":"Limit exceeded"}`, "LimitExceededException", false, translator.ErrQuotaExceeded},
+		{400, "", `{"__type":"DetectedLanguageLowConfidenceException","message":"Low confidence"}`, "DetectedLanguageLowConfidenceException", false, nil},
+		{503, "", `{"__type":"ServiceUnavailableException","message":"Unavailable"}`, "ServiceUnavailableException", true, nil},
+	} {
+		resp := &http.Response{
+			StatusCode: tc.statusCode,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+
+		if tc.header != "" {
+			resp.Header.Set(errorTypeHeader, tc.header)
+		}
+
+		if want, have := tc.retryable, retryable(resp, nil); have != want {
+			t.Fatalf("Unexpected retryable result for %s. Got: %t. Want: %t.", tc.code, have, want)
+		}
+
+		_, err := parseResponse(resp, &translationPayload{})
+
+		apiErr := &translator.APIError{}
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Unexpected error type. Got: %T. Want: *translator.APIError.", err)
+		}
+
+		if apiErr.Code != tc.code {
+			t.Fatalf("Unexpected code. Got: %s. Want: %s.", apiErr.Code, tc.code)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Fatalf("Unexpected retryable flag for %s. Got: %t. Want: %t.", tc.code, apiErr.Retryable, tc.retryable)
+		}
+
+		if apiErr.Err != tc.err {
+			t.Fatalf("Unexpected sentinel error for %s. Got: %v. Want: %v.", tc.code, apiErr.Err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package aws
+
+import "fmt"
+
+const service = "translate"
+
+// Amazon Translate expects the operation in the X-Amz-Target header of
+// requests to its only endpoint.
+const (
+	targetHeader        = "X-Amz-Target"
+	translateTextTarget = "AWSShineFrontendService_20170701.TranslateText"
+	listLanguagesTarget = "AWSShineFrontendService_20170701.ListLanguages"
+)
+
+type router struct {
+	endpoint string
+}
+
+func newRouter(endpoint string) *router {
+	return &router{
+		endpoint: endpoint,
+	}
+}
+
+// endpointFor returns the endpoint of Amazon Translate in the given region.
+func endpointFor(region string) string {
+	return fmt.Sprintf("https://translate.%s.amazonaws.com/", region)
+}
+
+func (r *router) url() string {
+	return r.endpoint
+}
@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/st3v/tracerr"
+	_http "github.com/st3v/translator/http"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+	shortDateFormat  = "20060102"
+)
+
+type signer struct {
+	credentials Credentials
+	region      string
+	service     string
+	now         func() time.Time
+}
+
+// NewSigner returns an authenticator that signs requests to the given AWS
+// service in the given region by means of Signature Version 4.
+// See https://docs.aws.amazon.com/general/latest/gr/signature-version-4.html
+func NewSigner(credentials Credentials, region, service string) _http.Authenticator {
+	return &signer{
+		credentials: credentials,
+		region:      region,
+		service:     service,
+		now:         time.Now,
+	}
+}
+
+func (s *signer) Authenticate(request *http.Request) error {
+	payload, err := readBody(request)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	now := s.now().UTC()
+	request.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+
+	if s.credentials.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", s.credentials.SessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalHeaders(request)
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI(request),
+		canonicalQuery(request),
+		canonicalHeaders,
+		signedHeaders,
+		hashHex(payload),
+	}, "\n")
+
+	scope := strings.Join([]string{
+		now.Format(shortDateFormat),
+		s.region,
+		s.service,
+		"aws4_request",
+	}, "/")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(now), stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm,
+		s.credentials.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+
+	return nil
+}
+
+func (s *signer) signingKey(now time.Time) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.credentials.SecretAccessKey), now.Format(shortDateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// readBody returns the body of the given request and restores it, so that
+// it can still be sent after signing.
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	payload, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	request.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	return payload, err
+}
+
+// canonicalURI returns the URI-encoded path of the given request. Services
+// other than S3 expect the already escaped path to be encoded once more.
+func canonicalURI(request *http.Request) string {
+	path := request.URL.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return uriEncode(path, false)
+}
+
+func canonicalQuery(request *http.Request) string {
+	params := request.URL.Query()
+
+	pairs := make([]string, 0, len(params))
+	for key, values := range params {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// canonicalHeaders returns the list of signed headers as well as their
+// canonical form. The host, the content type and all headers specific to
+// AWS are signed.
+func canonicalHeaders(request *http.Request) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for key, values := range request.Header {
+		name := strings.ToLower(key)
+		if name != "content-type" && !strings.HasPrefix(name, "x-amz-") {
+			continue
+		}
+
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// uriEncode encodes all characters except the unreserved ones as specified
+// by RFC 3986. Slashes are only encoded if encodeSlash is true.
+func uriEncode(s string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
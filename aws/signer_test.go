@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The example request of the AWS documentation, see
+// https://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
+func TestSignerAuthenticate(t *testing.T) {
+	request, err := http.NewRequest("GET", "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	s := NewSigner(Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "iam").(*signer)
+
+	s.now = func() time.Time {
+		return time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC)
+	}
+
+	if err := s.Authenticate(request); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if want, have := "20150830T123600Z", request.Header.Get("X-Amz-Date"); have != want {
+		t.Fatalf("Unexpected X-Amz-Date header. Got: %s. Want: %s.", have, want)
+	}
+
+	expected := "AWS4-HMAC-SHA256 " +
+		"Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+
+	if have := request.Header.Get("Authorization"); have != expected {
+		t.Fatalf("Unexpected Authorization header.\nGot:  %s\nWant: %s", have, expected)
+	}
+}
+
+func TestSignerAuthenticateSessionToken(t *testing.T) {
+	request, err := http.NewRequest("POST", "https://translate.eu-west-1.amazonaws.com/", strings.NewReader(`{"Text":"Hello"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	s := NewSigner(Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "session-token",
+	}, "eu-west-1", "translate")
+
+	if err := s.Authenticate(request); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if want, have := "session-token", request.Header.Get("X-Amz-Security-Token"); have != want {
+		t.Fatalf("Unexpected X-Amz-Security-Token header. Got: %s. Want: %s.", have, want)
+	}
+
+	if !strings.Contains(request.Header.Get("Authorization"), "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Fatalf("Session token should have been signed. Got: %s", request.Header.Get("Authorization"))
+	}
+
+	body := make([]byte, 64)
+	n, _ := request.Body.Read(body)
+	if want, have := `{"Text":"Hello"}`, string(body[:n]); have != want {
+		t.Fatalf("Request body should have been restored. Got: %s. Want: %s.", have, want)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	for _, tc := range []struct {
+		input       string
+		encodeSlash bool
+		expected    string
+	}{
+		{"/", false, "/"},
+		{"/a b/c~d", false, "/a%20b/c~d"},
+		{"a/b+c=d", true, "a%2Fb%2Bc%3Dd"},
+		{"/%C3%BC", false, "/%25C3%25BC"},
+	} {
+		if have := uriEncode(tc.input, tc.encodeSlash); have != tc.expected {
+			t.Fatalf("Unexpected encoding of %q. Got: %s. Want: %s.", tc.input, have, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+const contentType = "application/x-amz-json-1.1"
+
+// autoSource makes Amazon Translate detect the source language.
+const autoSource = "auto"
+
+// detectionTarget is the target language of the translations requested in
+// order to detect the language of a text.
+const detectionTarget = "en"
+
+type translationSettings struct {
+	Profanity string
+}
+
+type translationRequest struct {
+	Text               string
+	SourceLanguageCode string
+	TargetLanguageCode string
+	Settings           *translationSettings `json:",omitempty"`
+	TerminologyNames   []string             `json:",omitempty"`
+}
+
+type translationPayload struct {
+	TranslatedText     string
+	SourceLanguageCode string
+	TargetLanguageCode string
+}
+
+type translationProvider interface {
+	translate(ctx context.Context, text, from, to string) (string, error)
+	translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error)
+	detect(ctx context.Context, text string) (string, error)
+}
+
+type concreteTranslationProvider struct {
+	httpClient http.Client
+	router     *router
+}
+
+func newTranslationProvider(c http.Client, r *router) *concreteTranslationProvider {
+	return &concreteTranslationProvider{
+		httpClient: c,
+		router:     r,
+	}
+}
+
+func (t *concreteTranslationProvider) translate(ctx context.Context, text, from, to string) (string, error) {
+	return t.translateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+}
+
+// translateWithOptions requests the translation of the given text. The API
+// supports the Glossary option, i.e. the name of a custom terminology, and
+// masks profanities if asked to mark them. It is unable to delete them.
+func (t *concreteTranslationProvider) translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error) {
+	if err := opts.Validate(provider, "Profanity", "Glossary"); err != nil {
+		return "", err
+	}
+
+	if opts.Profanity == translator.ProfanityDelete {
+		return "", &translator.OptionError{
+			Provider: provider,
+			Option:   "Profanity",
+			Value:    opts.Profanity.String(),
+		}
+	}
+
+	payload, err := t.send(ctx, text, from, to, opts)
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	return payload.TranslatedText, nil
+}
+
+// detect translates the given text and returns the source language detected
+// by Amazon Translate, which has no dedicated detection operation. The
+// characters of the text therefore count towards the usage.
+func (t *concreteTranslationProvider) detect(ctx context.Context, text string) (string, error) {
+	payload, err := t.send(ctx, text, autoSource, detectionTarget, translator.TranslateOptions{})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	if payload.SourceLanguageCode == "" {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	return payload.SourceLanguageCode, nil
+}
+
+// send requests the translation of the given text. The source language is
+// detected if from is empty.
+func (t *concreteTranslationProvider) send(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (*translationPayload, error) {
+	if from == "" {
+		from = autoSource
+	}
+
+	request := &translationRequest{
+		Text:               text,
+		SourceLanguageCode: from,
+		TargetLanguageCode: to,
+	}
+
+	if opts.Profanity == translator.ProfanityMark {
+		request.Settings = &translationSettings{Profanity: "MASK"}
+	}
+
+	if opts.Glossary != "" {
+		request.TerminologyNames = []string{opts.Glossary}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	resp, err := t.httpClient.SendRequest(http.ContextWithTexts(ctx, text), "POST", t.router.url(), bytes.NewReader(body), contentType)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &translationPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*translationPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,256 @@
+// Package cache provides a Translator that caches the translations of any
+// other Translator, e.g. one returned by the google or microsoft packages,
+// to avoid paying for translating the same text over and over again.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+)
+
+// Key identifies a cached translation.
+type Key struct {
+	// Provider is the name of the translation API, e.g. "google".
+	Provider string
+
+	// From and To are the canonical BCP 47 tags of the source and target
+	// language. From is empty if the source language is detected.
+	From string
+	To   string
+
+	// Hash is the hex encoded SHA-256 hash of the original text.
+	Hash string
+}
+
+func newKey(provider, text, from, to string) Key {
+	hash := sha256.Sum256([]byte(text))
+	return Key{
+		Provider: provider,
+		From:     translator.CanonicalTag(from),
+		To:       translator.CanonicalTag(to),
+		Hash:     hex.EncodeToString(hash[:]),
+	}
+}
+
+// A Store persists translations. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Get returns the translation stored for the given key. The returned
+	// bool is false if there is no such translation.
+	Get(key Key) (string, bool, error)
+
+	// Set stores the translation for the given key.
+	Set(key Key, translation string) error
+}
+
+// Stats holds the number of cache hits and misses of a Translator.
+type Stats struct {
+	// Hits is the number of translations served from the store.
+	Hits int64
+
+	// Misses is the number of translations requested from the wrapped
+	// Translator.
+	Misses int64
+
+	// Errors is the number of failed store operations. Translations are
+	// requested from the wrapped Translator if they cannot be read from the
+	// store.
+	Errors int64
+}
+
+// Translator caches the translations of another Translator. It implements
+// translator.ContextTranslator and translator.BatchTranslator, regardless of
+// the wrapped Translator. Languages and language detections are not cached.
+type Translator struct {
+	// accessed atomically, kept first to be 64-bit aligned on 32-bit platforms
+	hits   int64
+	misses int64
+	errors int64
+
+	translator translator.Translator
+	provider   string
+	store      Store
+}
+
+// NewTranslator returns a Translator that looks up translations in the given
+// store before passing calls on to the given Translator. Translations are
+// stored under the given provider name, which allows several translators to
+// share the same store.
+func NewTranslator(t translator.Translator, provider string, store Store) *Translator {
+	return &Translator{
+		translator: t,
+		provider:   provider,
+		store:      store,
+	}
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (t *Translator) Stats() Stats {
+	return Stats{
+		Hits:   atomic.LoadInt64(&t.hits),
+		Misses: atomic.LoadInt64(&t.misses),
+		Errors: atomic.LoadInt64(&t.errors),
+	}
+}
+
+// Languages implements translator.Translator.
+func (t *Translator) Languages() ([]translator.Language, error) {
+	return t.LanguagesContext(context.Background())
+}
+
+// Translate implements translator.Translator.
+func (t *Translator) Translate(text, from, to string) (string, error) {
+	return t.TranslateContext(context.Background(), text, from, to)
+}
+
+// Detect implements translator.Translator.
+func (t *Translator) Detect(text string) (string, error) {
+	return t.DetectContext(context.Background(), text)
+}
+
+// TranslateBatch implements translator.BatchTranslator.
+func (t *Translator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return t.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+// LanguagesContext implements translator.ContextTranslator.
+func (t *Translator) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		return ct.LanguagesContext(ctx)
+	}
+	return t.translator.Languages()
+}
+
+// DetectContext implements translator.ContextTranslator.
+func (t *Translator) DetectContext(ctx context.Context, text string) (string, error) {
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		return ct.DetectContext(ctx, text)
+	}
+	return t.translator.Detect(text)
+}
+
+// TranslateContext implements translator.ContextTranslator.
+func (t *Translator) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	key := newKey(t.provider, text, from, to)
+
+	if translation, ok := t.get(key); ok {
+		return translation, nil
+	}
+
+	var (
+		translation string
+		err         error
+	)
+
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		translation, err = ct.TranslateContext(ctx, text, from, to)
+	} else {
+		translation, err = t.translator.Translate(text, from, to)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	t.set(key, translation)
+	return translation, nil
+}
+
+// TranslateBatchContext implements translator.BatchTranslator. Only the
+// texts whose translations are not cached are passed on to the wrapped
+// Translator.
+func (t *Translator) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	translations := make([]string, len(texts))
+
+	var (
+		keys    []Key
+		indexes []int
+		missing []string
+	)
+
+	for i, text := range texts {
+		key := newKey(t.provider, text, from, to)
+
+		if translation, ok := t.get(key); ok {
+			translations[i] = translation
+			continue
+		}
+
+		keys = append(keys, key)
+		indexes = append(indexes, i)
+		missing = append(missing, text)
+	}
+
+	if len(missing) == 0 {
+		return translations, nil
+	}
+
+	var (
+		results []string
+		err     error
+	)
+
+	if bt, ok := t.translator.(translator.BatchTranslator); ok {
+		results, err = bt.TranslateBatchContext(ctx, missing, from, to)
+	} else {
+		results, err = translateEach(ctx, t.translator, missing, from, to)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(missing) {
+		return nil, tracerr.Errorf("Unexpected number of translations: %d. Expected: %d.", len(results), len(missing))
+	}
+
+	for i, translation := range results {
+		translations[indexes[i]] = translation
+		t.set(keys[i], translation)
+	}
+
+	return translations, nil
+}
+
+func (t *Translator) get(key Key) (string, bool) {
+	translation, ok, err := t.store.Get(key)
+	if err != nil {
+		atomic.AddInt64(&t.errors, 1)
+	}
+
+	if err != nil || !ok {
+		atomic.AddInt64(&t.misses, 1)
+		return "", false
+	}
+
+	atomic.AddInt64(&t.hits, 1)
+	return translation, true
+}
+
+func (t *Translator) set(key Key, translation string) {
+	if err := t.store.Set(key, translation); err != nil {
+		atomic.AddInt64(&t.errors, 1)
+	}
+}
+
+func translateEach(ctx context.Context, t translator.Translator, texts []string, from, to string) ([]string, error) {
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		var err error
+		if ct, ok := t.(translator.ContextTranslator); ok {
+			translations[i], err = ct.TranslateContext(ctx, text, from, to)
+		} else {
+			translations[i], err = t.Translate(text, from, to)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+	return translations, nil
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+type mockTranslator struct {
+	mutex   sync.Mutex
+	texts   []string
+	batches int
+	err     error
+}
+
+func (m *mockTranslator) Languages() ([]translator.Language, error) {
+	return nil, nil
+}
+
+func (m *mockTranslator) Detect(text string) (string, error) {
+	return "de", nil
+}
+
+func (m *mockTranslator) Translate(text, from, to string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.err != nil {
+		return "", m.err
+	}
+
+	m.texts = append(m.texts, text)
+	return strings.ToUpper(text) + "@" + to, nil
+}
+
+type mockBatchTranslator struct {
+	mockTranslator
+}
+
+func (m *mockBatchTranslator) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	return m.Translate(text, from, to)
+}
+
+func (m *mockBatchTranslator) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return m.Languages()
+}
+
+func (m *mockBatchTranslator) DetectContext(ctx context.Context, text string) (string, error) {
+	return m.Detect(text)
+}
+
+func (m *mockBatchTranslator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return m.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+func (m *mockBatchTranslator) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	m.mutex.Lock()
+	m.batches++
+	m.mutex.Unlock()
+
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translation, err := m.Translate(text, from, to)
+		if err != nil {
+			return nil, err
+		}
+		translations[i] = translation
+	}
+	return translations, nil
+}
+
+type failingStore struct{}
+
+func (s failingStore) Get(key Key) (string, bool, error) {
+	return "", false, errors.New("fake-get-error")
+}
+
+func (s failingStore) Set(key Key, translation string) error {
+	return errors.New("fake-set-error")
+}
+
+func TestTranslatorTranslate(t *testing.T) {
+	mock := &mockTranslator{}
+	cached := NewTranslator(mock, "mock", NewMemoryStore(10, 0))
+
+	for i := 0; i < 3; i++ {
+		translation, err := cached.Translate("hallo", "de", "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if translation != "HALLO@en" {
+			t.Fatalf("Unexpected translation. Got: %s. Want: HALLO@en.", translation)
+		}
+	}
+
+	// language codes are compared by their canonical tags
+	if _, err := cached.Translate("hallo", "DE", "EN"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if _, err := cached.Translate("hallo", "de", "fr"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(mock.texts, []string{"hallo", "hallo"}) {
+		t.Fatalf("Unexpected translations requested from wrapped translator: %v", mock.texts)
+	}
+
+	if stats := cached.Stats(); stats != (Stats{Hits: 3, Misses: 2}) {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestTranslatorProviders(t *testing.T) {
+	store := NewMemoryStore(10, 0)
+
+	first := &mockTranslator{}
+	second := &mockTranslator{}
+
+	for _, tr := range []*Translator{
+		NewTranslator(first, "first", store),
+		NewTranslator(second, "second", store),
+	} {
+		if _, err := tr.Translate("hallo", "de", "en"); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+	}
+
+	if len(first.texts) != 1 || len(second.texts) != 1 {
+		t.Fatalf("Expected each provider to be asked once. Got: %v, %v", first.texts, second.texts)
+	}
+
+	if store.Len() != 2 {
+		t.Fatalf("Unexpected number of stored translations: %d", store.Len())
+	}
+}
+
+func TestTranslatorTranslateError(t *testing.T) {
+	mock := &mockTranslator{err: errors.New("fake-error")}
+	store := NewMemoryStore(10, 0)
+	cached := NewTranslator(mock, "mock", store)
+
+	if _, err := cached.Translate("hallo", "de", "en"); err == nil || err.Error() != "fake-error" {
+		t.Fatalf("Expected fake-error. Got: %v", err)
+	}
+
+	if store.Len() != 0 {
+		t.Fatalf("Errors should not be cached. Stored translations: %d", store.Len())
+	}
+}
+
+func TestTranslatorStoreError(t *testing.T) {
+	mock := &mockTranslator{}
+	cached := NewTranslator(mock, "mock", failingStore{})
+
+	translation, err := cached.Translate("hallo", "de", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if translation != "HALLO@en" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: HALLO@en.", translation)
+	}
+
+	if stats := cached.Stats(); stats != (Stats{Misses: 1, Errors: 2}) {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestTranslatorTranslateBatch(t *testing.T) {
+	for _, mock := range []translator.Translator{&mockTranslator{}, &mockBatchTranslator{}} {
+		cached := NewTranslator(mock, "mock", NewMemoryStore(10, 0))
+
+		if _, err := cached.Translate("welt", "de", "en"); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		translations, err := cached.TranslateBatch([]string{"hallo", "welt", "!"}, "de", "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if want := []string{"HALLO@en", "WELT@en", "!@en"}; !reflect.DeepEqual(translations, want) {
+			t.Fatalf("Unexpected translations. Got: %v. Want: %v.", translations, want)
+		}
+
+		var texts []string
+		switch m := mock.(type) {
+		case *mockTranslator:
+			texts = m.texts
+		case *mockBatchTranslator:
+			texts = m.texts
+			if m.batches != 1 {
+				t.Fatalf("Expected 1 batch request but counted %d.", m.batches)
+			}
+		}
+
+		if want := []string{"welt", "hallo", "!"}; !reflect.DeepEqual(texts, want) {
+			t.Fatalf("Unexpected translations requested from wrapped translator. Got: %v. Want: %v.", texts, want)
+		}
+
+		if stats := cached.Stats(); stats != (Stats{Hits: 1, Misses: 3}) {
+			t.Fatalf("Unexpected stats: %+v", stats)
+		}
+	}
+}
+
+func TestTranslatorPassThrough(t *testing.T) {
+	cached := NewTranslator(&mockTranslator{}, "mock", NewMemoryStore(10, 0))
+
+	language, err := cached.Detect("hallo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if language != "de" {
+		t.Fatalf("Unexpected language. Got: %s. Want: de.", language)
+	}
+
+	var _ translator.ContextTranslator = cached
+	var _ translator.BatchTranslator = cached
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/st3v/tracerr"
+)
+
+// DiskStore keeps translations in files below a directory, which allows
+// translations to survive restarts and to be shared by several processes.
+// Each translation is stored in a file of its own. It is safe for concurrent
+// use.
+type DiskStore struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewDiskStore returns a DiskStore that keeps translations below the given
+// directory, which is created if it does not exist. Translations older than
+// the given TTL are ignored. Translations never expire if the TTL is not
+// positive.
+func NewDiskStore(dir string, ttl time.Duration) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return &DiskStore{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}, nil
+}
+
+// Get implements Store.
+func (s *DiskStore) Get(key Key) (string, bool, error) {
+	path := s.path(key)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, tracerr.Wrap(err)
+	}
+
+	if s.ttl > 0 && s.now().Sub(info.ModTime()) >= s.ttl {
+		return "", false, nil
+	}
+
+	translation, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, tracerr.Wrap(err)
+	}
+
+	return string(translation), true, nil
+}
+
+// Set implements Store. The translation is written to a temporary file that
+// is renamed afterwards, i.e. readers never see partially written files.
+func (s *DiskStore) Set(key Key, translation string) error {
+	path := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	_, err = file.WriteString(translation)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(file.Name())
+		return tracerr.Wrap(err)
+	}
+
+	return nil
+}
+
+// path returns the file of the given key. Keys are hashed since providers
+// and language codes might contain characters that are not allowed in file
+// names. Files are spread across subdirectories to keep directories small.
+func (s *DiskStore) path(key Key) string {
+	hash := sha256.Sum256([]byte(key.Provider + "\x00" + key.From + "\x00" + key.To + "\x00" + key.Hash))
+	name := hex.EncodeToString(hash[:])
+	return filepath.Join(s.dir, name[:2], name)
+}
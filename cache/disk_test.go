@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiskStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "translator-cache")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	dir = filepath.Join(dir, "translations")
+
+	store, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	key := newKey("mock", "hallo", "de", "en")
+
+	if _, ok, err := store.Get(key); ok || err != nil {
+		t.Fatalf("Unexpected translation for unknown key. Got: %t, %v.", ok, err)
+	}
+
+	if err := store.Set(key, "hello"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if err := store.Set(key, "hello world"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// translations survive the store
+	store, err = NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	translation, ok, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !ok || translation != "hello world" {
+		t.Fatalf("Unexpected translation. Got: %s, %t. Want: hello world, true.", translation, ok)
+	}
+
+	if _, ok, _ := store.Get(newKey("other", "hallo", "de", "en")); ok {
+		t.Fatal("Translations of other providers should not be returned.")
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*", ".tmp-*"))
+	if len(matches) != 0 {
+		t.Fatalf("Temporary files should have been removed: %v", matches)
+	}
+}
+
+func TestDiskStoreTTL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "translator-cache")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewDiskStore(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	key := newKey("mock", "hallo", "de", "en")
+	if err := store.Set(key, "hello"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if _, ok, _ := store.Get(key); !ok {
+		t.Fatal("Translation should not have expired yet.")
+	}
+
+	store.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	if _, ok, _ := store.Get(key); ok {
+		t.Fatal("Translation should have expired.")
+	}
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps translations in memory. Once it holds its maximum number
+// of translations, the least recently used translation is evicted to make
+// room for a new one. It is safe for concurrent use.
+type MemoryStore struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[Key]*list.Element
+	lru     *list.List
+}
+
+type memoryEntry struct {
+	key         Key
+	translation string
+	stored      time.Time
+}
+
+// NewMemoryStore returns a MemoryStore that holds at most the given number of
+// translations, each for at most the given TTL. The number of translations
+// is not limited if capacity is not positive, and translations never expire
+// if the TTL is not positive.
+func NewMemoryStore(capacity int, ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[Key]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(key Key) (string, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return "", false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if s.ttl > 0 && s.now().Sub(entry.stored) >= s.ttl {
+		s.remove(element)
+		return "", false, nil
+	}
+
+	s.lru.MoveToFront(element)
+	return entry.translation, true, nil
+}
+
+// Set implements Store.
+func (s *MemoryStore) Set(key Key, translation string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.translation = translation
+		entry.stored = s.now()
+		s.lru.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(&memoryEntry{
+		key:         key,
+		translation: translation,
+		stored:      s.now(),
+	})
+
+	if s.capacity > 0 && s.lru.Len() > s.capacity {
+		s.remove(s.lru.Back())
+	}
+
+	return nil
+}
+
+// Len returns the number of translations in the store, including expired
+// ones that have not been evicted yet.
+func (s *MemoryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.lru.Len()
+}
+
+func (s *MemoryStore) remove(element *list.Element) {
+	s.lru.Remove(element)
+	delete(s.entries, element.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreLRU(t *testing.T) {
+	store := NewMemoryStore(2, 0)
+
+	first := newKey("mock", "first", "de", "en")
+	second := newKey("mock", "second", "de", "en")
+	third := newKey("mock", "third", "de", "en")
+
+	store.Set(first, "1")
+	store.Set(second, "2")
+
+	// make second the least recently used translation
+	if translation, ok, _ := store.Get(first); !ok || translation != "1" {
+		t.Fatalf("Unexpected translation. Got: %s, %t. Want: 1, true.", translation, ok)
+	}
+
+	store.Set(third, "3")
+
+	if _, ok, _ := store.Get(second); ok {
+		t.Fatal("Least recently used translation should have been evicted.")
+	}
+
+	for key, want := range map[Key]string{first: "1", third: "3"} {
+		if translation, ok, _ := store.Get(key); !ok || translation != want {
+			t.Fatalf("Unexpected translation. Got: %s, %t. Want: %s, true.", translation, ok, want)
+		}
+	}
+
+	if store.Len() != 2 {
+		t.Fatalf("Unexpected number of translations: %d", store.Len())
+	}
+}
+
+func TestMemoryStoreTTL(t *testing.T) {
+	now := time.Now()
+
+	store := NewMemoryStore(0, time.Hour)
+	store.now = func() time.Time { return now }
+
+	key := newKey("mock", "hallo", "de", "en")
+	store.Set(key, "hello")
+
+	now = now.Add(59 * time.Minute)
+	if _, ok, _ := store.Get(key); !ok {
+		t.Fatal("Translation should not have expired yet.")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := store.Get(key); ok {
+		t.Fatal("Translation should have expired.")
+	}
+
+	if store.Len() != 0 {
+		t.Fatalf("Expired translation should have been evicted. Translations: %d", store.Len())
+	}
+}
@@ -0,0 +1,200 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultCatalogTTL is the time for which a Catalog considers the languages
+// it obtained from an API to be up to date.
+const DefaultCatalogTTL = 24 * time.Hour
+
+// A Catalog caches the languages supported by a translation API separately
+// for each display locale. It is safe for concurrent use. Concurrent calls
+// that find no cached languages share a single request to the API.
+//
+// Once languages are older than the catalog's TTL, they are refreshed in the
+// background while the catalog keeps serving the stale languages. Failed
+// refreshes do not evict cached languages, i.e. the catalog serves stale
+// languages rather than errors for as long as the API is unavailable.
+//
+// Catalogs created by means of NewValueCatalog cache arbitrary values, e.g.
+// the scripts supported by an API, rather than languages.
+type Catalog struct {
+	fetch func(ctx context.Context, key string) (interface{}, error)
+	ttl   time.Duration
+	now   func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*catalogEntry
+}
+
+type catalogEntry struct {
+	value   interface{}
+	fetched time.Time
+	cached  bool
+	refresh *catalogRefresh
+}
+
+type catalogRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+// NewCatalog returns a Catalog that obtains the languages for a given display
+// locale by means of the passed fetch function and refreshes them once they
+// are older than the given TTL. Languages never expire if the TTL is not
+// positive.
+func NewCatalog(ttl time.Duration, fetch func(ctx context.Context, displayLocale string) ([]Language, error)) *Catalog {
+	return NewValueCatalog(ttl, func(ctx context.Context, displayLocale string) (interface{}, error) {
+		return fetch(ctx, displayLocale)
+	})
+}
+
+// NewValueCatalog returns a Catalog that obtains the value for a given key
+// by means of the passed fetch function and refreshes it once it is older
+// than the given TTL. Values never expire if the TTL is not positive.
+func NewValueCatalog(ttl time.Duration, fetch func(ctx context.Context, key string) (interface{}, error)) *Catalog {
+	return &Catalog{
+		fetch:   fetch,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*catalogEntry{},
+	}
+}
+
+// Languages returns the languages named in the given display locale. Cached
+// languages are returned right away, even if they are stale. Otherwise, the
+// languages are fetched, or the call waits for a fetch that is already in
+// progress, until the given context is done.
+func (c *Catalog) Languages(ctx context.Context, displayLocale string) ([]Language, error) {
+	value, err := c.Value(ctx, displayLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, _ := value.([]Language)
+	return languages, nil
+}
+
+// Value returns the value cached under the given key. Cached values are
+// returned right away, even if they are stale. Otherwise, the value is
+// fetched, or the call waits for a fetch that is already in progress, until
+// the given context is done.
+func (c *Catalog) Value(ctx context.Context, key string) (interface{}, error) {
+	for {
+		c.mutex.Lock()
+
+		entry, ok := c.entries[key]
+		if !ok {
+			entry = &catalogEntry{}
+			c.entries[key] = entry
+		}
+
+		if entry.cached {
+			value := entry.value
+			if c.expired(entry) && entry.refresh == nil {
+				c.start(context.Background(), key, entry)
+			}
+			c.mutex.Unlock()
+			return value, nil
+		}
+
+		refresh, shared := entry.refresh, entry.refresh != nil
+		if !shared {
+			refresh = c.start(ctx, key, entry)
+		}
+		c.mutex.Unlock()
+
+		value, err := c.wait(ctx, entry, refresh)
+
+		// a fetch started by another caller might have failed because that
+		// caller's context is done, try again unless ours is done, too
+		if shared && isContextError(err) && ctx.Err() == nil {
+			continue
+		}
+
+		return value, err
+	}
+}
+
+// Refresh fetches the languages of all display locales, or the values of all
+// keys, that have been requested so far and waits for the fetches to
+// complete. Cached values remain in place if their refresh fails. The first
+// error is returned.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	c.mutex.Lock()
+	entries := make(map[*catalogEntry]*catalogRefresh, len(c.entries))
+	for key, entry := range c.entries {
+		refresh := entry.refresh
+		if refresh == nil {
+			refresh = c.start(ctx, key, entry)
+		}
+		entries[entry] = refresh
+	}
+	c.mutex.Unlock()
+
+	var result error
+	for entry, refresh := range entries {
+		if _, err := c.wait(ctx, entry, refresh); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+func (c *Catalog) expired(entry *catalogEntry) bool {
+	return c.ttl > 0 && c.now().Sub(entry.fetched) >= c.ttl
+}
+
+// start fetches the value of the given entry in a separate goroutine. The
+// caller must hold the catalog's mutex.
+func (c *Catalog) start(ctx context.Context, key string, entry *catalogEntry) *catalogRefresh {
+	refresh := &catalogRefresh{done: make(chan struct{})}
+	entry.refresh = refresh
+
+	go func() {
+		value, err := c.fetch(ctx, key)
+
+		c.mutex.Lock()
+		if err == nil {
+			entry.value = value
+			entry.fetched = c.now()
+			entry.cached = true
+		} else if !entry.cached && c.entries[key] == entry {
+			// forget keys that never had a value, e.g. unsupported display
+			// locales, so that they neither fail nor bloat later refreshes
+			delete(c.entries, key)
+		}
+		entry.refresh = nil
+		refresh.err = err
+		c.mutex.Unlock()
+
+		close(refresh.done)
+	}()
+
+	return refresh
+}
+
+func (c *Catalog) wait(ctx context.Context, entry *catalogEntry, refresh *catalogRefresh) (interface{}, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-refresh.done:
+	}
+
+	if refresh.err != nil {
+		return nil, refresh.err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return entry.value, nil
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
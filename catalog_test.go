@@ -0,0 +1,340 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeFetcher struct {
+	mutex   sync.Mutex
+	calls   map[string]int
+	err     error
+	name    string
+	release chan struct{}
+}
+
+func newFakeFetcher() *fakeFetcher {
+	return &fakeFetcher{
+		calls: map[string]int{},
+		name:  "German",
+	}
+}
+
+func (f *fakeFetcher) fetch(ctx context.Context, displayLocale string) ([]Language, error) {
+	f.mutex.Lock()
+	f.calls[displayLocale]++
+	release, err, name := f.release, f.err, f.name
+	f.mutex.Unlock()
+
+	if release != nil {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []Language{{Code: "de", Name: name + " (" + displayLocale + ")", Tag: "de"}}, nil
+}
+
+func (f *fakeFetcher) callCount(displayLocale string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.calls[displayLocale]
+}
+
+func (f *fakeFetcher) set(name string, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.name, f.err = name, err
+}
+
+// waitForRefresh blocks until no refresh of the given display locale is in
+// progress anymore.
+func waitForRefresh(c *Catalog, displayLocale string) {
+	c.mutex.Lock()
+	entry := c.entries[displayLocale]
+	var refresh *catalogRefresh
+	if entry != nil {
+		refresh = entry.refresh
+	}
+	c.mutex.Unlock()
+
+	if refresh != nil {
+		<-refresh.done
+	}
+}
+
+func TestCatalogConcurrentLanguages(t *testing.T) {
+	fetcher := newFakeFetcher()
+	fetcher.release = make(chan struct{})
+
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			languages, err := catalog.Languages(context.Background(), "en")
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err.Error())
+				return
+			}
+
+			if len(languages) != 1 || languages[0].Name != "German (en)" {
+				t.Errorf("Unexpected languages: %v", languages)
+			}
+		}()
+	}
+
+	// give the goroutines a chance to pile up behind the first fetch
+	time.Sleep(10 * time.Millisecond)
+	close(fetcher.release)
+	wg.Wait()
+
+	if count := fetcher.callCount("en"); count != 1 {
+		t.Fatalf("Expected 1 fetch but counted %d.", count)
+	}
+}
+
+func TestCatalogDisplayLocales(t *testing.T) {
+	fetcher := newFakeFetcher()
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+
+	for _, displayLocale := range []string{"en", "de", "en", "de"} {
+		languages, err := catalog.Languages(context.Background(), displayLocale)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if want := "German (" + displayLocale + ")"; languages[0].Name != want {
+			t.Fatalf("Unexpected language name. Got: %s. Want: %s.", languages[0].Name, want)
+		}
+	}
+
+	for _, displayLocale := range []string{"en", "de"} {
+		if count := fetcher.callCount(displayLocale); count != 1 {
+			t.Fatalf("Expected 1 fetch for %s but counted %d.", displayLocale, count)
+		}
+	}
+}
+
+func TestCatalogTTL(t *testing.T) {
+	now := time.Now()
+
+	fetcher := newFakeFetcher()
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+	catalog.now = func() time.Time { return now }
+
+	if _, err := catalog.Languages(context.Background(), "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// stale languages are served while they are refreshed in the background
+	fetcher.set("Deutsch", nil)
+	now = now.Add(time.Hour)
+
+	languages, err := catalog.Languages(context.Background(), "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if languages[0].Name != "German (en)" {
+		t.Fatalf("Expected stale language name. Got: %s.", languages[0].Name)
+	}
+
+	waitForRefresh(catalog, "en")
+
+	languages, err = catalog.Languages(context.Background(), "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if languages[0].Name != "Deutsch (en)" {
+		t.Fatalf("Expected refreshed language name. Got: %s.", languages[0].Name)
+	}
+
+	if count := fetcher.callCount("en"); count != 2 {
+		t.Fatalf("Expected 2 fetches but counted %d.", count)
+	}
+}
+
+func TestCatalogStaleWhileError(t *testing.T) {
+	now := time.Now()
+
+	fetcher := newFakeFetcher()
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+	catalog.now = func() time.Time { return now }
+
+	if _, err := catalog.Languages(context.Background(), "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	fetcher.set("Deutsch", errors.New("fake-error"))
+	now = now.Add(2 * time.Hour)
+
+	for i := 0; i < 3; i++ {
+		languages, err := catalog.Languages(context.Background(), "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if languages[0].Name != "German (en)" {
+			t.Fatalf("Expected stale language name. Got: %s.", languages[0].Name)
+		}
+
+		waitForRefresh(catalog, "en")
+	}
+
+	if err := catalog.Refresh(context.Background()); err == nil || err.Error() != "fake-error" {
+		t.Fatalf("Expected fake-error from Refresh. Got: %v", err)
+	}
+
+	languages, err := catalog.Languages(context.Background(), "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if languages[0].Name != "German (en)" {
+		t.Fatalf("Expected stale language name after failed refresh. Got: %s.", languages[0].Name)
+	}
+}
+
+func TestCatalogError(t *testing.T) {
+	fetcher := newFakeFetcher()
+	fetcher.set("German", errors.New("fake-error"))
+
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+
+	if _, err := catalog.Languages(context.Background(), "en"); err == nil || err.Error() != "fake-error" {
+		t.Fatalf("Expected fake-error. Got: %v", err)
+	}
+
+	// errors are not cached
+	fetcher.set("German", nil)
+
+	if _, err := catalog.Languages(context.Background(), "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if count := fetcher.callCount("en"); count != 2 {
+		t.Fatalf("Expected 2 fetches but counted %d.", count)
+	}
+}
+
+func TestCatalogRefresh(t *testing.T) {
+	fetcher := newFakeFetcher()
+	catalog := NewCatalog(0, fetcher.fetch)
+
+	for _, displayLocale := range []string{"en", "de"} {
+		if _, err := catalog.Languages(context.Background(), displayLocale); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+	}
+
+	fetcher.set("Deutsch", nil)
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	for _, displayLocale := range []string{"en", "de"} {
+		languages, err := catalog.Languages(context.Background(), displayLocale)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if want := "Deutsch (" + displayLocale + ")"; languages[0].Name != want {
+			t.Fatalf("Unexpected language name. Got: %s. Want: %s.", languages[0].Name, want)
+		}
+
+		if count := fetcher.callCount(displayLocale); count != 2 {
+			t.Fatalf("Expected 2 fetches for %s but counted %d.", displayLocale, count)
+		}
+	}
+}
+
+func TestCatalogCancelled(t *testing.T) {
+	fetcher := newFakeFetcher()
+	fetcher.release = make(chan struct{})
+	defer close(fetcher.release)
+
+	catalog := NewCatalog(time.Hour, fetcher.fetch)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := catalog.Languages(ctx, "en")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline to be exceeded. Got: %v", err)
+	}
+}
+
+func TestCatalogValue(t *testing.T) {
+	calls := 0
+	catalog := NewValueCatalog(time.Hour, func(ctx context.Context, key string) (interface{}, error) {
+		calls++
+		return map[string]int{key: calls}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		value, err := catalog.Value(context.Background(), "scripts")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if count := value.(map[string]int)["scripts"]; count != 1 {
+			t.Fatalf("Unexpected value. Got: %d. Want: 1.", count)
+		}
+	}
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	value, err := catalog.Value(context.Background(), "scripts")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if count := value.(map[string]int)["scripts"]; count != 2 {
+		t.Fatalf("Unexpected value after refresh. Got: %d. Want: 2.", count)
+	}
+}
+
+func TestCatalogRefreshAfterError(t *testing.T) {
+	catalog := NewCatalog(time.Hour, func(ctx context.Context, displayLocale string) ([]Language, error) {
+		if displayLocale != "en" {
+			return nil, errors.New("fake-error")
+		}
+		return []Language{{Code: "de", Name: "German", Tag: "de"}}, nil
+	})
+
+	if _, err := catalog.Languages(context.Background(), "xx"); err == nil || err.Error() != "fake-error" {
+		t.Fatalf("Expected fake-error. Got: %v", err)
+	}
+
+	if _, err := catalog.Languages(context.Background(), "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	if _, ok := catalog.entries["xx"]; ok || len(catalog.entries) != 1 {
+		t.Fatalf("Unexpected catalog entries: %v", catalog.entries)
+	}
+}
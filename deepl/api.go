@@ -0,0 +1,78 @@
+package deepl
+
+import (
+	"context"
+
+	"github.com/st3v/translator"
+)
+
+type api struct {
+	lp languageProvider
+	tp translationProvider
+}
+
+// NewTranslator instantiates a new Translator for DeepL's API. Requests are
+// sent to the free or the pro API depending on the given authentication key.
+// Language codes are returned in lower case, e.g. de or en-gb.
+// DeepL has no dedicated detection endpoint, hence Detect translates the
+// given text, which counts towards the usage limit.
+// The returned Translator also implements translator.ContextTranslator,
+// translator.BatchTranslator, translator.OptionsTranslator, and
+// translator.LanguageRefresher.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslator(authKey string, opts ...Option) translator.Translator {
+	options := newOptions(authKey, opts)
+	httpClient := options.httpClient()
+	router := newRouter(options.baseURL)
+
+	return &api{
+		lp: newLanguageProvider(httpClient, router, options.catalogTTL),
+		tp: newTranslationProvider(httpClient, router, options.formality, options.glossary),
+	}
+}
+
+func (a *api) Languages() ([]translator.Language, error) {
+	return a.LanguagesContext(context.Background())
+}
+
+func (a *api) Detect(text string) (string, error) {
+	return a.DetectContext(context.Background(), text)
+}
+
+func (a *api) Translate(text, from, to string) (string, error) {
+	return a.TranslateContext(context.Background(), text, from, to)
+}
+
+func (a *api) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return a.lp.languages(ctx)
+}
+
+func (a *api) RefreshLanguages(ctx context.Context) error {
+	return a.lp.refresh(ctx)
+}
+
+func (a *api) DetectContext(ctx context.Context, text string) (string, error) {
+	return a.tp.detect(ctx, text)
+}
+
+func (a *api) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	return a.tp.translate(ctx, text, codeMap.Code(from), codeMap.Code(to))
+}
+
+func (a *api) TranslateWithOptions(ctx context.Context, req translator.TranslateRequest) (translator.TranslateResult, error) {
+	text, err := a.tp.translateWithOptions(ctx, req.Text, codeMap.Code(req.From), codeMap.Code(req.To), req.TranslateOptions)
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	return translator.TranslateResult{Text: text}, nil
+}
+
+func (a *api) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return a.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+func (a *api) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return a.tp.translateBatch(ctx, texts, codeMap.Code(from), codeMap.Code(to))
+}
@@ -0,0 +1,22 @@
+package deepl
+
+import (
+	"net/http"
+
+	_http "github.com/st3v/translator/http"
+)
+
+type authenticator struct {
+	authKey string
+}
+
+func newAuthenticator(authKey string) _http.Authenticator {
+	return &authenticator{
+		authKey: authKey,
+	}
+}
+
+func (a *authenticator) Authenticate(request *http.Request) error {
+	request.Header.Set("Authorization", "DeepL-Auth-Key "+a.authKey)
+	return nil
+}
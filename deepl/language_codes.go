@@ -0,0 +1,9 @@
+package deepl
+
+import "github.com/st3v/translator"
+
+// codeMap maps canonical BCP 47 tags to the language codes of DeepL's API
+// where the two differ.
+var codeMap = translator.CodeMap{
+	"zh-Hans": "zh",
+}
@@ -0,0 +1,107 @@
+package deepl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+type languagesPayload []struct {
+	Language string
+	Name     string
+}
+
+type languageProvider interface {
+	languages(ctx context.Context) ([]translator.Language, error)
+	refresh(ctx context.Context) error
+}
+
+type concreteLanguageProvider struct {
+	router     *router
+	httpClient http.Client
+	catalog    *translator.Catalog
+}
+
+func newLanguageProvider(c http.Client, r *router, ttl time.Duration) *concreteLanguageProvider {
+	p := &concreteLanguageProvider{
+		router:     r,
+		httpClient: c,
+	}
+	p.catalog = translator.NewCatalog(ttl, p.fetchLanguages)
+	return p
+}
+
+func (p *concreteLanguageProvider) languages(ctx context.Context) ([]translator.Language, error) {
+	return p.catalog.Languages(ctx, "en")
+}
+
+func (p *concreteLanguageProvider) refresh(ctx context.Context) error {
+	return p.catalog.Refresh(ctx)
+}
+
+// fetchLanguages returns the union of source and target languages, since DeepL
+// distinguishes regional variants of some target languages, e.g. en-gb and
+// en-us, but only accepts the general language as source, e.g. en.
+func (p *concreteLanguageProvider) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	names := make(map[string]string)
+
+	for _, languageType := range []string{"source", "target"} {
+		payload, err := p.fetch(ctx, languageType)
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		for _, l := range payload {
+			names[strings.ToLower(l.Language)] = l.Name
+		}
+	}
+
+	codes := make([]string, 0, len(names))
+	for code := range names {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	languages := make([]translator.Language, len(codes))
+	for i, code := range codes {
+		languages[i] = translator.Language{
+			Code: code,
+			Name: names[code],
+			Tag:  codeMap.Tag(code),
+		}
+	}
+
+	return languages, nil
+}
+
+func (p *concreteLanguageProvider) fetch(ctx context.Context, languageType string) (languagesPayload, error) {
+	resp, err := p.httpClient.SendRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s?type=%s", p.router.languagesURL(), languageType),
+		nil,
+		"text/plain",
+	)
+
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &languagesPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*languagesPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return *payload, nil
+}
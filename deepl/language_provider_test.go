@@ -0,0 +1,69 @@
+package deepl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func TestLanguages(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "GET" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("type") {
+		case "source":
+			fmt.Fprint(w, `[
+				{"language": "DE", "name": "German"},
+				{"language": "EN", "name": "English"}
+			]`)
+		case "target":
+			fmt.Fprint(w, `[
+				{"language": "DE", "name": "German", "supports_formality": true},
+				{"language": "EN-GB", "name": "English (British)", "supports_formality": false},
+				{"language": "EN-US", "name": "English (American)", "supports_formality": false}
+			]`)
+		default:
+			t.Fatalf("Unexpected `type` param in request: %s", r.URL.Query().Get("type"))
+		}
+	}))
+	defer server.Close()
+
+	router := &router{languagesEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	expectedLanguages := []translator.Language{
+		{Code: "de", Name: "German", Tag: "de"},
+		{Code: "en", Name: "English", Tag: "en"},
+		{Code: "en-gb", Name: "English (British)", Tag: "en-GB"},
+		{Code: "en-us", Name: "English (American)", Tag: "en-US"},
+	}
+
+	for i := 0; i < 2; i++ {
+		actualLanguages, err := provider.languages(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(actualLanguages, expectedLanguages) {
+			t.Fatalf("Unexpected languages. Got: %v. Want: %v.", actualLanguages, expectedLanguages)
+		}
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
@@ -0,0 +1,139 @@
+package deepl
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// Formality controls whether translations lean towards formal or informal
+// language. It only applies to target languages that distinguish between
+// formal and informal forms, e.g. German, French or Japanese.
+type Formality string
+
+// Formalities supported by DeepL. The Prefer variants fall back to the
+// default for target languages without formal and informal forms, whereas
+// the others make DeepL reject requests for such languages.
+const (
+	FormalityDefault    Formality = "default"
+	FormalityMore       Formality = "more"
+	FormalityLess       Formality = "less"
+	FormalityPreferMore Formality = "prefer_more"
+	FormalityPreferLess Formality = "prefer_less"
+)
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	baseURL       string
+	formality     Formality
+	glossary      string
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	clientOptions []http.ClientOption
+}
+
+func newOptions(authKey string, opts []Option) *options {
+	o := &options{
+		baseURL:       baseURLFor(authKey),
+		authenticator: newAuthenticator(authKey),
+		retryPolicy:   http.DefaultRetryPolicy(),
+		catalogTTL:    translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o *options) httpClient() http.Client {
+	clientOptions := append([]http.ClientOption{http.WithRetryPolicy(o.retryPolicy)}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithFormality makes the Translator request translations with the given
+// formality.
+func WithFormality(formality Formality) Option {
+	return func(o *options) {
+		o.formality = formality
+	}
+}
+
+// WithGlossary makes the Translator apply the glossary with the given ID.
+// DeepL only applies glossaries if the source language is given and matches
+// the glossary's source language.
+func WithGlossary(glossaryID string) Option {
+	return func(o *options) {
+		o.glossary = glossaryID
+	}
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithBaseURL makes the Translator send requests to the given base URL
+// instead of https://api-free.deepl.com/v2/ for free authentication keys,
+// i.e. keys ending with ":fx", or https://api.deepl.com/v2/ otherwise.
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		o.baseURL = url
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that adds the
+// authentication key to every request.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
@@ -0,0 +1,80 @@
+package deepl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_http "github.com/st3v/translator/http"
+)
+
+func TestBaseURLFor(t *testing.T) {
+	if want, have := freeBaseURL, baseURLFor("my-key:fx"); have != want {
+		t.Fatalf("Unexpected base URL for free key. Got: %s. Want: %s.", have, want)
+	}
+
+	if want, have := proBaseURL, baseURLFor("my-key"); have != want {
+		t.Fatalf("Unexpected base URL for pro key. Got: %s. Want: %s.", have, want)
+	}
+}
+
+func TestNewTranslatorOptions(t *testing.T) {
+	expectedUserAgent := "fake-user-agent"
+	expectedTranslation := "Hallo Welt!"
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.URL.Path != "/v2/translate" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.Header.Get("User-Agent") != expectedUserAgent {
+			t.Fatalf("Unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+
+		if r.Header.Get("Authorization") != "DeepL-Auth-Key my-key:fx" {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		if r.FormValue("formality") != string(FormalityMore) {
+			t.Fatalf("Unexpected `formality` param in request: %s", r.FormValue("formality"))
+		}
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"translations": [{"detected_source_language": "EN", "text": "%s"}]}`, expectedTranslation)
+	}))
+	defer server.Close()
+
+	translator := NewTranslator(
+		"my-key:fx",
+		WithBaseURL(server.URL+"/v2"),
+		WithHTTPClient(server.Client()),
+		WithTimeout(time.Second),
+		WithUserAgent(expectedUserAgent),
+		WithFormality(FormalityMore),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+
+	actualTranslation, err := translator.Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", actualTranslation, expectedTranslation)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
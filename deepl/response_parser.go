@@ -0,0 +1,70 @@
+package deepl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const provider = "deepl"
+
+// DeepL responds with status 456 if the character limit has been reached.
+const statusQuotaExceeded = 456
+
+type errorPayload struct {
+	Message string
+	Detail  string
+}
+
+var parseResponse = func(resp *http.Response, target interface{}) (interface{}, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(resp, body)
+	}
+
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return target, nil
+}
+
+// newAPIError classifies the error described by the given response.
+// See https://www.deepl.com/docs-api/api-access/general-information/
+func newAPIError(resp *http.Response, body []byte) *translator.APIError {
+	err := _http.NewAPIError(provider, resp)
+
+	payload := &errorPayload{}
+	if json.Unmarshal(body, payload) == nil && payload.Message != "" {
+		err.Message = payload.Message
+		if payload.Detail != "" {
+			err.Message += " " + payload.Detail
+		}
+	} else if len(body) > 0 {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		err.Err = translator.ErrUnauthorized
+	case statusQuotaExceeded:
+		err.Err = translator.ErrQuotaExceeded
+	case http.StatusBadRequest:
+		if strings.Contains(err.Message, "_lang") {
+			err.Err = translator.ErrUnsupportedLanguage
+		}
+	}
+
+	return err
+}
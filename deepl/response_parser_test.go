@@ -0,0 +1,58 @@
+package deepl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestParseResponseError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		body       string
+		message    string
+		retryable  bool
+		err        error
+	}{
+		{http.StatusForbidden, "", "403 Forbidden", false, translator.ErrUnauthorized},
+		{statusQuotaExceeded, `{"message":"Quota Exceeded"}`, "Quota Exceeded", false, translator.ErrQuotaExceeded},
+		{http.StatusTooManyRequests, `{"message":"Too many requests"}`, "Too many requests", true, translator.ErrQuotaExceeded},
+		{http.StatusBadRequest, `{"message":"Value for 'target_lang' not supported."}`, "Value for 'target_lang' not supported.", false, translator.ErrUnsupportedLanguage},
+		{http.StatusBadRequest, `{"message":"Bad request","detail":"Parameter 'text' not specified."}`, "Bad request Parameter 'text' not specified.", false, nil},
+		{529, "Too many requests", "Too many requests", true, nil},
+	} {
+		resp := &http.Response{
+			StatusCode: tc.statusCode,
+			Status:     fmt.Sprintf("%d %s", tc.statusCode, http.StatusText(tc.statusCode)),
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+
+		_, err := parseResponse(resp, &translationPayload{})
+
+		apiErr := &translator.APIError{}
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Unexpected error type. Got: %T. Want: *translator.APIError.", err)
+		}
+
+		if apiErr.Provider != provider {
+			t.Fatalf("Unexpected provider. Got: %s. Want: %s.", apiErr.Provider, provider)
+		}
+
+		if apiErr.Message != tc.message {
+			t.Fatalf("Unexpected message. Got: %s. Want: %s.", apiErr.Message, tc.message)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Fatalf("Unexpected retryable flag for status %d. Got: %t. Want: %t.", tc.statusCode, apiErr.Retryable, tc.retryable)
+		}
+
+		if apiErr.Err != tc.err {
+			t.Fatalf("Unexpected sentinel error for status %d. Got: %v. Want: %v.", tc.statusCode, apiErr.Err, tc.err)
+		}
+	}
+}
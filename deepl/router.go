@@ -0,0 +1,44 @@
+package deepl
+
+import "strings"
+
+const (
+	freeBaseURL = "https://api-free.deepl.com/v2/"
+	proBaseURL  = "https://api.deepl.com/v2/"
+)
+
+// Authentication keys for DeepL's free API end with ":fx".
+const freeAuthKeySuffix = ":fx"
+
+type router struct {
+	languagesEndpoint string
+	translateEndpoint string
+}
+
+func newRouter(baseURL string) *router {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &router{
+		languagesEndpoint: baseURL + "languages",
+		translateEndpoint: baseURL + "translate",
+	}
+}
+
+// baseURLFor returns the base URL of the free API for free authentication
+// keys and the base URL of the pro API otherwise.
+func baseURLFor(authKey string) string {
+	if strings.HasSuffix(authKey, freeAuthKeySuffix) {
+		return freeBaseURL
+	}
+	return proBaseURL
+}
+
+func (r *router) languagesURL() string {
+	return r.languagesEndpoint
+}
+
+func (r *router) translateURL() string {
+	return r.translateEndpoint
+}
@@ -0,0 +1,179 @@
+package deepl
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// DeepL accepts at most 50 texts and a request body of 128 KiB per
+// translation request. The character limit leaves room for multi-byte
+// characters and their URL encoding.
+const (
+	maxBatchTexts = 50
+	maxBatchChars = 10000
+)
+
+// detectionTarget is the target language of the translations requested in
+// order to detect the language of a text.
+const detectionTarget = "EN-US"
+
+type translation struct {
+	DetectedSourceLanguage string `json:"detected_source_language"`
+	Text                   string
+}
+
+type translationPayload struct {
+	Translations []translation
+}
+
+type translationProvider interface {
+	translate(ctx context.Context, text, from, to string) (string, error)
+	translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error)
+	translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error)
+	detect(ctx context.Context, text string) (string, error)
+}
+
+type concreteTranslationProvider struct {
+	httpClient http.Client
+	router     *router
+	formality  Formality
+	glossary   string
+}
+
+func newTranslationProvider(c http.Client, r *router, formality Formality, glossary string) *concreteTranslationProvider {
+	return &concreteTranslationProvider{
+		httpClient: c,
+		router:     r,
+		formality:  formality,
+		glossary:   glossary,
+	}
+}
+
+func (t *concreteTranslationProvider) translate(ctx context.Context, text, from, to string) (string, error) {
+	return t.translateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+}
+
+// translateWithOptions requests the translation of the given text. The API
+// supports the Format and Glossary options. The glossary takes precedence
+// over the glossary the translator has been configured with and requires a
+// source language.
+func (t *concreteTranslationProvider) translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error) {
+	if err := opts.Validate(provider, "Format", "Glossary"); err != nil {
+		return "", err
+	}
+
+	if opts.Glossary != "" && from == "" {
+		return "", tracerr.Error("Glossaries require a source language.")
+	}
+
+	translations, err := t.send(ctx, t.params(from, to, opts), []string{text})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	return translations[0].Text, nil
+}
+
+func (t *concreteTranslationProvider) translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	result := make([]string, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		translations, err := t.send(ctx, t.params(from, to, translator.TranslateOptions{}), batch)
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		for _, translation := range translations {
+			result = append(result, translation.Text)
+		}
+	}
+
+	return result, nil
+}
+
+// detect translates the given text and returns the source language detected
+// by DeepL, which has no dedicated detection endpoint. The characters of the
+// text therefore count towards the usage limit.
+func (t *concreteTranslationProvider) detect(ctx context.Context, text string) (string, error) {
+	params := url.Values{}
+	params.Set("target_lang", detectionTarget)
+
+	translations, err := t.send(ctx, params, []string{text})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	if translations[0].DetectedSourceLanguage == "" {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	return strings.ToLower(translations[0].DetectedSourceLanguage), nil
+}
+
+// params returns the request parameters for translations from one language
+// into another. The source language is detected if from is empty. The
+// glossary requires a source language and is omitted otherwise.
+func (t *concreteTranslationProvider) params(from, to string, opts translator.TranslateOptions) url.Values {
+	params := url.Values{}
+	params.Set("target_lang", strings.ToUpper(to))
+
+	glossary := t.glossary
+	if opts.Glossary != "" {
+		glossary = opts.Glossary
+	}
+
+	if from != "" {
+		params.Set("source_lang", strings.ToUpper(from))
+
+		if glossary != "" {
+			params.Set("glossary_id", glossary)
+		}
+	}
+
+	if opts.Format == translator.HTML {
+		params.Set("tag_handling", "html")
+	}
+
+	if t.formality != "" {
+		params.Set("formality", string(t.formality))
+	}
+
+	return params
+}
+
+// send requests the translation of the given texts and returns exactly one
+// translation per text.
+func (t *concreteTranslationProvider) send(ctx context.Context, params url.Values, texts []string) ([]translation, error) {
+	for _, text := range texts {
+		params.Add("text", text)
+	}
+
+	resp, err := t.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, texts...),
+		"POST",
+		t.router.translateURL(),
+		strings.NewReader(params.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &translationPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*translationPayload)
+	if !ok || len(payload.Translations) != len(texts) {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return payload.Translations, nil
+}
@@ -0,0 +1,241 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func writeTranslations(t *testing.T, w http.ResponseWriter, detected string, texts []string) {
+	payload := translationPayload{}
+	for _, text := range texts {
+		payload.Translations = append(payload.Translations, translation{
+			DetectedSourceLanguage: detected,
+			Text:                   text,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		t.Fatalf("Unexpected error encoding response: %s", err.Error())
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	expectedOriginal := "Rindfleischetikettierungsüberwachungsaufgabenübertragungsgesetz"
+	expectedTranslation := "WTF!?!"
+
+	expectedAuthKey := "my-secret-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		if r.Header.Get("Authorization") != "DeepL-Auth-Key "+expectedAuthKey {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		for param, expected := range map[string]string{
+			"source_lang": "DE",
+			"target_lang": "EN-GB",
+			"text":        expectedOriginal,
+			"formality":   "prefer_less",
+			"glossary_id": "my-glossary",
+		} {
+			if r.FormValue(param) != expected {
+				t.Fatalf("Unexpected `%s` param in request. Got: %s. Want: %s", param, r.FormValue(param), expected)
+			}
+		}
+
+		writeTranslations(t, w, "DE", []string{expectedTranslation})
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator(expectedAuthKey)), router, FormalityPreferLess, "my-glossary")
+
+	actualTranslation, err := provider.translate(context.Background(), expectedOriginal, "de", "en-gb")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslateWithoutSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %s", err.Error())
+		}
+
+		for _, param := range []string{"source_lang", "glossary_id"} {
+			if _, ok := r.PostForm[param]; ok {
+				t.Fatalf("Unexpected `%s` param in request: %v", param, r.PostForm)
+			}
+		}
+
+		writeTranslations(t, w, "DE", []string{"Hello"})
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router, "", "my-glossary")
+
+	actualTranslation, err := provider.translate(context.Background(), "Hallo", "", "en-us")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != "Hello" {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, "Hello")
+	}
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for param, expected := range map[string]string{
+			"source_lang":  "DE",
+			"glossary_id":  "other-glossary",
+			"tag_handling": "html",
+		} {
+			if r.FormValue(param) != expected {
+				t.Fatalf("Unexpected `%s` param in request. Got: %s. Want: %s", param, r.FormValue(param), expected)
+			}
+		}
+
+		writeTranslations(t, w, "DE", []string{"<b>Hello</b>"})
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router, "", "my-glossary")
+
+	opts := translator.TranslateOptions{Format: translator.HTML, Glossary: "other-glossary"}
+
+	actualTranslation, err := provider.translateWithOptions(context.Background(), "<b>Hallo</b>", "de", "en-us", opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != "<b>Hello</b>" {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, "<b>Hello</b>")
+	}
+
+	if _, err := provider.translateWithOptions(context.Background(), "Hallo", "", "en-us", opts); err == nil {
+		t.Fatal("Expected an error for a glossary without source language.")
+	}
+
+	_, err = provider.translateWithOptions(context.Background(), "Hallo", "de", "en-us", translator.TranslateOptions{Model: "nmt"})
+	if !errors.Is(err, translator.ErrUnsupportedOption) {
+		t.Fatalf("Expected ErrUnsupportedOption. Got: %v", err)
+	}
+}
+
+func TestTranslateBatch(t *testing.T) {
+	originals := make([]string, maxBatchTexts+2)
+	expectedTranslations := make([]string, len(originals))
+	for i := range originals {
+		originals[i] = fmt.Sprintf("Text %d", i)
+		expectedTranslations[i] = fmt.Sprintf("Translation %d", i)
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %s", err.Error())
+		}
+
+		translations := make([]string, len(r.PostForm["text"]))
+		for i, text := range r.PostForm["text"] {
+			translations[i] = strings.Replace(text, "Text", "Translation", 1)
+		}
+
+		writeTranslations(t, w, "EN", translations)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router, "", "")
+
+	actualTranslations, err := provider.translateBatch(context.Background(), originals, "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(actualTranslations, expectedTranslations) {
+		t.Fatalf("Unexpected translations. Got: %v. Want: %v.", actualTranslations, expectedTranslations)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %s", err.Error())
+		}
+
+		if r.PostForm.Get("target_lang") != detectionTarget {
+			t.Fatalf("Unexpected `target_lang` param in request. Got: %s. Want: %s", r.PostForm.Get("target_lang"), detectionTarget)
+		}
+
+		for _, param := range []string{"source_lang", "formality", "glossary_id"} {
+			if _, ok := r.PostForm[param]; ok {
+				t.Fatalf("Unexpected `%s` param in request: %v", param, r.PostForm)
+			}
+		}
+
+		writeTranslations(t, w, "DE", []string{"Hello World!"})
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router, FormalityMore, "my-glossary")
+
+	actualLanguage, err := provider.detect(context.Background(), "Hallo Welt!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualLanguage != "de" {
+		t.Fatalf("Unexpected language. Got: %s. Want: %s.", actualLanguage, "de")
+	}
+}
+
+func TestTranslateQuotaExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusQuotaExceeded)
+		fmt.Fprint(w, `{"message":"Quota Exceeded"}`)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router, "", "")
+
+	_, err := provider.translate(context.Background(), "Hello", "en", "de")
+	if !errors.Is(err, translator.ErrQuotaExceeded) {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, translator.ErrQuotaExceeded)
+	}
+}
@@ -0,0 +1,99 @@
+package translator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors that classify the errors returned by translation APIs.
+// Use errors.Is to check whether an error falls into one of these categories.
+var (
+	// ErrUnauthorized indicates that the API rejected the credentials
+	// used to authenticate the request.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrQuotaExceeded indicates that a quota or rate limit of the API
+	// has been exceeded.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+
+	// ErrUnsupportedLanguage indicates that the API does not support one
+	// of the requested languages or language pairs.
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+
+	// ErrNoDetection indicates that the API was unable to detect the
+	// language of the given text.
+	ErrNoDetection = errors.New("no language detected")
+
+	// ErrLowConfidence indicates that the API detected the source language
+	// of a text with less confidence than required.
+	ErrLowConfidence = errors.New("detection confidence too low")
+
+	// ErrUnsupportedOption indicates that the API does not support one of
+	// the requested TranslateOptions.
+	ErrUnsupportedOption = errors.New("unsupported option")
+)
+
+// The OptionError struct represents a TranslateOption that a translator does
+// not support. It wraps ErrUnsupportedOption.
+type OptionError struct {
+	// Provider is the name of the translation API, e.g. "google".
+	Provider string
+
+	// Option is the name of the unsupported option, e.g. Category.
+	Option string
+
+	// Value is the requested value of the option.
+	Value string
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("%s API does not support option %s=%s", e.Provider, e.Option, e.Value)
+}
+
+// Unwrap returns ErrUnsupportedOption.
+func (e *OptionError) Unwrap() error {
+	return ErrUnsupportedOption
+}
+
+// The APIError struct represents an error returned by a translation API.
+// Use errors.As to obtain the details of an API error.
+type APIError struct {
+	// Provider is the name of the translation API, e.g. "google".
+	Provider string
+
+	// StatusCode is the HTTP status code of the API response.
+	StatusCode int
+
+	// Code is the provider-specific error code, if any.
+	Code string
+
+	// Reason is the provider-specific reason for the error, if any.
+	Reason string
+
+	// Message is the human-readable error message returned by the API.
+	Message string
+
+	// Retryable indicates whether repeating the request at a later point
+	// in time might succeed.
+	Retryable bool
+
+	// Err is one of the sentinel errors defined in this package, or nil
+	// if the error does not fall into any of their categories.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf(
+		"%s API error. Status: %d, Code: %s, Reason: %s, Message: %s",
+		e.Provider,
+		e.StatusCode,
+		e.Code,
+		e.Reason,
+		e.Message,
+	)
+}
+
+// Unwrap returns the sentinel error that classifies the API error.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,49 @@
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	var err error = &APIError{
+		Provider:   "fake-provider",
+		StatusCode: 403,
+		Err:        ErrQuotaExceeded,
+	}
+
+	err = fmt.Errorf("wrapped: %w", err)
+
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected error to be ErrQuotaExceeded: %s", err.Error())
+	}
+
+	if errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected error not to be ErrUnauthorized: %s", err.Error())
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected error to be an APIError: %s", err.Error())
+	}
+
+	if apiErr.Provider != "fake-provider" || apiErr.StatusCode != 403 {
+		t.Errorf("Unexpected APIError: %#v", apiErr)
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	err := &APIError{
+		Provider:   "fake-provider",
+		StatusCode: 400,
+		Code:       "fake-code",
+		Reason:     "fake-reason",
+		Message:    "fake-message",
+	}
+
+	expected := "fake-provider API error. Status: 400, Code: fake-code, Reason: fake-reason, Message: fake-message"
+	if err.Error() != expected {
+		t.Errorf("Unexpected error message. Got: '%s'. Want: '%s'.", err.Error(), expected)
+	}
+}
@@ -5,14 +5,19 @@ import (
 	"sync"
 
 	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
 	"github.com/st3v/translator/microsoft"
 )
 
 // Instantiates a translator that is backed by the Microsoft Translation API and passes it to helloWorld.
 // Get your own subscription key by registering a Microsoft Text Translation service in Azure.
 // See http://docs.microsofttranslator.com/text-translate.html.
+// The translator is rate limited to 10 requests per second to stay within the API's quota.
 func main() {
-	translator := microsoft.NewTranslator("your-subscription-key")
+	translator := microsoft.NewTranslator(
+		"your-subscription-key",
+		microsoft.WithRateLimiter(http.NewRateLimiter(10, 0)),
+	)
 	helloWorld(translator)
 }
 
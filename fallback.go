@@ -0,0 +1,280 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Backend is a named Translator that takes part in a Fallback.
+type Backend struct {
+	// Name identifies the backend in results and errors, e.g. "microsoft".
+	Name string
+
+	// Translator is the translation service of the backend.
+	Translator Translator
+
+	// Codes maps the language codes used by callers of the Fallback to the
+	// codes expected by the backend, e.g. "zh-Hant" to "zh-CHT". Codes that
+	// are not part of the map are passed on as is. Codes returned by the
+	// backend are mapped back accordingly.
+	Codes map[string]string
+}
+
+func (b Backend) toBackend(code string) string {
+	if mapped, ok := b.Codes[code]; ok {
+		return mapped
+	}
+	return code
+}
+
+// fromBackend maps the given code of the backend to the code used by
+// callers. If several codes map to the same backend code, the first one in
+// lexical order is returned.
+func (b Backend) fromBackend(code string) string {
+	result := ""
+	for caller, backend := range b.Codes {
+		if backend == code && (result == "" || caller < result) {
+			result = caller
+		}
+	}
+
+	if result == "" {
+		return code
+	}
+	return result
+}
+
+// FallbackError is returned by a Fallback if all of its backends failed.
+type FallbackError struct {
+	// Backends holds the names of the backends in the order they were tried.
+	Backends []string
+
+	// Errors holds the error returned by each of the backends.
+	Errors []error
+}
+
+func (e *FallbackError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = fmt.Sprintf("%s: %s", e.Backends[i], err)
+	}
+	return "All backends failed. " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the error of the backend that has been tried last.
+func (e *FallbackError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors[len(e.Errors)-1]
+}
+
+// FallbackTranslator is a Translator that is composed of multiple backends.
+// See Fallback.
+type FallbackTranslator struct {
+	backends []Backend
+}
+
+// Fallback returns a Translator that sends requests to the primary backend
+// and falls back to the secondary backends in the given order as long as
+// the previous backend fails with an error that might be temporary, i.e. a
+// retryable APIError or an error that does not originate from the API, such
+// as a connection failure. Non-retryable API errors, e.g. unsupported
+// languages, and cancelled contexts are returned right away.
+// The returned Translator also implements ContextTranslator and
+// BatchTranslator, regardless of the interfaces the backends implement.
+func Fallback(primary Backend, secondaries ...Backend) *FallbackTranslator {
+	return &FallbackTranslator{
+		backends: append([]Backend{primary}, secondaries...),
+	}
+}
+
+// Languages implements Translator.
+func (f *FallbackTranslator) Languages() ([]Language, error) {
+	return f.LanguagesContext(context.Background())
+}
+
+// Translate implements Translator.
+func (f *FallbackTranslator) Translate(text, from, to string) (string, error) {
+	return f.TranslateContext(context.Background(), text, from, to)
+}
+
+// Detect implements Translator.
+func (f *FallbackTranslator) Detect(text string) (string, error) {
+	return f.DetectContext(context.Background(), text)
+}
+
+// TranslateBatch implements BatchTranslator.
+func (f *FallbackTranslator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return f.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+// LanguagesContext implements ContextTranslator.
+func (f *FallbackTranslator) LanguagesContext(ctx context.Context) ([]Language, error) {
+	languages, _, err := f.LanguagesWithBackend(ctx)
+	return languages, err
+}
+
+// TranslateContext implements ContextTranslator.
+func (f *FallbackTranslator) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	translation, _, err := f.TranslateWithBackend(ctx, text, from, to)
+	return translation, err
+}
+
+// DetectContext implements ContextTranslator.
+func (f *FallbackTranslator) DetectContext(ctx context.Context, text string) (string, error) {
+	language, _, err := f.DetectWithBackend(ctx, text)
+	return language, err
+}
+
+// TranslateBatchContext implements BatchTranslator.
+func (f *FallbackTranslator) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	translations, _, err := f.TranslateBatchWithBackend(ctx, texts, from, to)
+	return translations, err
+}
+
+// LanguagesWithBackend is like LanguagesContext but also returns the name of
+// the backend that served the result. Language codes are mapped back to the
+// codes used by callers.
+func (f *FallbackTranslator) LanguagesWithBackend(ctx context.Context) ([]Language, string, error) {
+	var languages []Language
+
+	backend, err := f.try(ctx, func(b Backend) error {
+		result, err := languagesContext(ctx, b.Translator)
+		if err != nil {
+			return err
+		}
+
+		languages = make([]Language, len(result))
+		for i, l := range result {
+			languages[i] = l
+			languages[i].Code = b.fromBackend(l.Code)
+		}
+		return nil
+	})
+
+	return languages, backend, err
+}
+
+// TranslateWithBackend is like TranslateContext but also returns the name of
+// the backend that served the translation.
+func (f *FallbackTranslator) TranslateWithBackend(ctx context.Context, text, from, to string) (string, string, error) {
+	var translation string
+
+	backend, err := f.try(ctx, func(b Backend) (err error) {
+		translation, err = translateContext(ctx, b.Translator, text, b.toBackend(from), b.toBackend(to))
+		return err
+	})
+
+	return translation, backend, err
+}
+
+// DetectWithBackend is like DetectContext but also returns the name of the
+// backend that detected the language. The language code is mapped back to
+// the code used by callers.
+func (f *FallbackTranslator) DetectWithBackend(ctx context.Context, text string) (string, string, error) {
+	var language string
+
+	backend, err := f.try(ctx, func(b Backend) error {
+		result, err := detectContext(ctx, b.Translator, text)
+		if err != nil {
+			return err
+		}
+
+		language = b.fromBackend(result)
+		return nil
+	})
+
+	return language, backend, err
+}
+
+// TranslateBatchWithBackend is like TranslateBatchContext but also returns
+// the name of the backend that served the translations. Backends that do not
+// implement BatchTranslator translate the texts one by one.
+func (f *FallbackTranslator) TranslateBatchWithBackend(ctx context.Context, texts []string, from, to string) ([]string, string, error) {
+	var translations []string
+
+	backend, err := f.try(ctx, func(b Backend) (err error) {
+		translations, err = translateBatchContext(ctx, b.Translator, texts, b.toBackend(from), b.toBackend(to))
+		return err
+	})
+
+	return translations, backend, err
+}
+
+// try calls the given function for one backend after the other until it
+// succeeds or fails with an error that rules out falling back. It returns
+// the name of the last backend it has tried.
+func (f *FallbackTranslator) try(ctx context.Context, fn func(Backend) error) (string, error) {
+	fallbackErr := &FallbackError{}
+
+	for _, b := range f.backends {
+		err := fn(b)
+		if err == nil {
+			return b.Name, nil
+		}
+
+		if !fallbackable(ctx, err) {
+			return b.Name, err
+		}
+
+		fallbackErr.Backends = append(fallbackErr.Backends, b.Name)
+		fallbackErr.Errors = append(fallbackErr.Errors, err)
+	}
+
+	return "", fallbackErr
+}
+
+// fallbackable reports whether another backend might succeed where one
+// failed with the given error.
+func fallbackable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || isContextError(err) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+
+	return true
+}
+
+func languagesContext(ctx context.Context, t Translator) ([]Language, error) {
+	if ct, ok := t.(ContextTranslator); ok {
+		return ct.LanguagesContext(ctx)
+	}
+	return t.Languages()
+}
+
+func translateContext(ctx context.Context, t Translator, text, from, to string) (string, error) {
+	if ct, ok := t.(ContextTranslator); ok {
+		return ct.TranslateContext(ctx, text, from, to)
+	}
+	return t.Translate(text, from, to)
+}
+
+func detectContext(ctx context.Context, t Translator, text string) (string, error) {
+	if ct, ok := t.(ContextTranslator); ok {
+		return ct.DetectContext(ctx, text)
+	}
+	return t.Detect(text)
+}
+
+func translateBatchContext(ctx context.Context, t Translator, texts []string, from, to string) ([]string, error) {
+	if bt, ok := t.(BatchTranslator); ok {
+		return bt.TranslateBatchContext(ctx, texts, from, to)
+	}
+
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translation, err := translateContext(ctx, t, text, from, to)
+		if err != nil {
+			return nil, err
+		}
+		translations[i] = translation
+	}
+	return translations, nil
+}
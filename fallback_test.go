@@ -0,0 +1,204 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mockTranslator struct {
+	languages func() ([]Language, error)
+	translate func(text, from, to string) (string, error)
+	detect    func(text string) (string, error)
+}
+
+func (m *mockTranslator) Languages() ([]Language, error) {
+	return m.languages()
+}
+
+func (m *mockTranslator) Translate(text, from, to string) (string, error) {
+	return m.translate(text, from, to)
+}
+
+func (m *mockTranslator) Detect(text string) (string, error) {
+	return m.detect(text)
+}
+
+func failingTranslator(err error, calls *int) *mockTranslator {
+	return &mockTranslator{
+		languages: func() ([]Language, error) { *calls++; return nil, err },
+		translate: func(text, from, to string) (string, error) { *calls++; return "", err },
+		detect:    func(text string) (string, error) { *calls++; return "", err },
+	}
+}
+
+func TestFallbackTranslate(t *testing.T) {
+	primaryCalls := 0
+	primary := failingTranslator(&APIError{Provider: "microsoft", StatusCode: 503, Retryable: true}, &primaryCalls)
+
+	secondary := &mockTranslator{
+		translate: func(text, from, to string) (string, error) {
+			if from != "zh-TW" || to != "en" {
+				t.Fatalf("Unexpected language codes. Got: %s, %s. Want: zh-TW, en.", from, to)
+			}
+			return "Hello", nil
+		},
+	}
+
+	f := Fallback(
+		Backend{Name: "microsoft", Translator: primary, Codes: map[string]string{"zh-Hant": "zh-CHT"}},
+		Backend{Name: "google", Translator: secondary, Codes: map[string]string{"zh-Hant": "zh-TW"}},
+	)
+
+	translation, backend, err := f.TranslateWithBackend(context.Background(), "你好", "zh-Hant", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if translation != "Hello" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", translation, "Hello")
+	}
+
+	if backend != "google" {
+		t.Fatalf("Unexpected backend. Got: %s. Want: %s.", backend, "google")
+	}
+
+	if primaryCalls != 1 {
+		t.Fatalf("Unexpected number of calls to primary backend. Got: %d. Want: %d.", primaryCalls, 1)
+	}
+}
+
+func TestFallbackNonRetryableError(t *testing.T) {
+	expectedErr := &APIError{Provider: "microsoft", StatusCode: 400, Err: ErrUnsupportedLanguage}
+
+	primaryCalls, secondaryCalls := 0, 0
+	f := Fallback(
+		Backend{Name: "microsoft", Translator: failingTranslator(expectedErr, &primaryCalls)},
+		Backend{Name: "google", Translator: failingTranslator(nil, &secondaryCalls)},
+	)
+
+	_, backend, err := f.TranslateWithBackend(context.Background(), "Hello", "en", "xx")
+	if err != expectedErr {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, expectedErr)
+	}
+
+	if backend != "microsoft" {
+		t.Fatalf("Unexpected backend. Got: %s. Want: %s.", backend, "microsoft")
+	}
+
+	if secondaryCalls != 0 {
+		t.Fatalf("Secondary backend should not have been called. Calls: %d.", secondaryCalls)
+	}
+}
+
+func TestFallbackAllBackendsFail(t *testing.T) {
+	connectionErr := errors.New("connection refused")
+	quotaErr := &APIError{Provider: "google", StatusCode: 429, Retryable: true, Err: ErrQuotaExceeded}
+
+	calls := 0
+	f := Fallback(
+		Backend{Name: "microsoft", Translator: failingTranslator(connectionErr, &calls)},
+		Backend{Name: "google", Translator: failingTranslator(quotaErr, &calls)},
+	)
+
+	_, err := f.Detect("Hello")
+
+	fallbackErr := &FallbackError{}
+	if !errors.As(err, &fallbackErr) {
+		t.Fatalf("Unexpected error type. Got: %T. Want: *FallbackError.", err)
+	}
+
+	if want := []string{"microsoft", "google"}; !reflect.DeepEqual(fallbackErr.Backends, want) {
+		t.Fatalf("Unexpected backends. Got: %v. Want: %v.", fallbackErr.Backends, want)
+	}
+
+	if want := []error{connectionErr, quotaErr}; !reflect.DeepEqual(fallbackErr.Errors, want) {
+		t.Fatalf("Unexpected errors. Got: %v. Want: %v.", fallbackErr.Errors, want)
+	}
+
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Error should wrap the error of the last backend. Got: %v.", err)
+	}
+}
+
+func TestFallbackCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	primaryCalls, secondaryCalls := 0, 0
+	f := Fallback(
+		Backend{Name: "microsoft", Translator: failingTranslator(context.Canceled, &primaryCalls)},
+		Backend{Name: "google", Translator: failingTranslator(nil, &secondaryCalls)},
+	)
+
+	_, err := f.TranslateContext(ctx, "Hello", "en", "de")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, context.Canceled)
+	}
+
+	if secondaryCalls != 0 {
+		t.Fatalf("Secondary backend should not have been called. Calls: %d.", secondaryCalls)
+	}
+}
+
+func TestFallbackDetectAndLanguagesMapCodes(t *testing.T) {
+	backend := Backend{
+		Name: "microsoft",
+		Translator: &mockTranslator{
+			languages: func() ([]Language, error) {
+				return []Language{{Code: "en", Name: "English", Tag: "en"}, {Code: "zh-CHT", Name: "Chinese Traditional", Tag: "zh-Hant"}}, nil
+			},
+			detect: func(text string) (string, error) { return "zh-CHT", nil },
+		},
+		Codes: map[string]string{"zh-TW": "zh-CHT", "zh-Hant": "zh-CHT"},
+	}
+
+	f := Fallback(backend)
+
+	language, name, err := f.DetectWithBackend(context.Background(), "你好")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if language != "zh-Hant" || name != "microsoft" {
+		t.Fatalf("Unexpected detection. Got: %s, %s. Want: zh-Hant, microsoft.", language, name)
+	}
+
+	languages, err := f.Languages()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if want := []Language{{Code: "en", Name: "English", Tag: "en"}, {Code: "zh-Hant", Name: "Chinese Traditional", Tag: "zh-Hant"}}; !reflect.DeepEqual(languages, want) {
+		t.Fatalf("Unexpected languages. Got: %v. Want: %v.", languages, want)
+	}
+}
+
+func TestFallbackTranslateBatch(t *testing.T) {
+	calls := 0
+	f := Fallback(Backend{
+		Name: "plain",
+		Translator: &mockTranslator{
+			translate: func(text, from, to string) (string, error) {
+				calls++
+				return text + "!", nil
+			},
+		},
+	})
+
+	var bt BatchTranslator = f
+
+	translations, err := bt.TranslateBatch([]string{"a", "b"}, "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if want := []string{"a!", "b!"}; !reflect.DeepEqual(translations, want) {
+		t.Fatalf("Unexpected translations. Got: %v. Want: %v.", translations, want)
+	}
+
+	if calls != 2 {
+		t.Fatalf("Unexpected number of calls. Got: %d. Want: %d.", calls, 2)
+	}
+}
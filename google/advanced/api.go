@@ -0,0 +1,101 @@
+package advanced
+
+import (
+	"context"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/google/auth"
+)
+
+type api struct {
+	lp languageProvider
+	tp translationProvider
+}
+
+// NewTranslator instantiates a new Translator for version 3 of Google's
+// Cloud Translation API, also known as Cloud Translation Advanced.
+// Requests are authorized with OAuth2 access tokens for the service account
+// described by the given JSON key file. If projectID is empty, the project
+// of the service account is used.
+// The returned Translator also implements translator.ContextTranslator,
+// translator.BatchTranslator, translator.OptionsTranslator, and
+// translator.LanguageRefresher.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslator(projectID string, credentials []byte, opts ...Option) (translator.Translator, error) {
+	options := newOptions(opts)
+
+	if projectID == "" {
+		account, err := auth.ParseServiceAccount(credentials)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		projectID = account.ProjectID
+	}
+
+	if projectID == "" {
+		return nil, tracerr.Error("Missing project ID.")
+	}
+
+	if options.authenticator == nil {
+		authenticator, err := auth.NewServiceAccountAuthenticator(credentials, auth.CloudTranslationScope)
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+		options.authenticator = authenticator
+	}
+
+	httpClient := options.httpClient()
+	router := newRouter(options.baseURL, projectID, options.location)
+
+	return &api{
+		lp: newLanguageProvider(httpClient, router, options.catalogTTL),
+		tp: newTranslationProvider(httpClient, router, options.model, options.glossary),
+	}, nil
+}
+
+func (a *api) Languages() ([]translator.Language, error) {
+	return a.LanguagesContext(context.Background())
+}
+
+func (a *api) Detect(text string) (string, error) {
+	return a.DetectContext(context.Background(), text)
+}
+
+func (a *api) Translate(text, from, to string) (string, error) {
+	return a.TranslateContext(context.Background(), text, from, to)
+}
+
+func (a *api) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return a.lp.languages(ctx)
+}
+
+func (a *api) RefreshLanguages(ctx context.Context) error {
+	return a.lp.refresh(ctx)
+}
+
+func (a *api) DetectContext(ctx context.Context, text string) (string, error) {
+	return a.lp.detect(ctx, text)
+}
+
+func (a *api) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	return a.tp.translate(ctx, text, codeMap.Code(from), codeMap.Code(to))
+}
+
+func (a *api) TranslateWithOptions(ctx context.Context, req translator.TranslateRequest) (translator.TranslateResult, error) {
+	text, err := a.tp.translateWithOptions(ctx, req.Text, codeMap.Code(req.From), codeMap.Code(req.To), req.TranslateOptions)
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	return translator.TranslateResult{Text: text}, nil
+}
+
+func (a *api) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return a.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+func (a *api) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return a.tp.translateBatch(ctx, texts, codeMap.Code(from), codeMap.Code(to))
+}
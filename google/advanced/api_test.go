@@ -0,0 +1,378 @@
+package advanced
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+type authenticatorFunc func(r *http.Request) error
+
+func (f authenticatorFunc) Authenticate(r *http.Request) error {
+	return f(r)
+}
+
+func newTestTranslator(t *testing.T, server *httptest.Server, opts ...Option) translator.Translator {
+	opts = append([]Option{
+		WithBaseURL(server.URL + "/v3"),
+		WithHTTPClient(server.Client()),
+		WithAuthenticator(authenticatorFunc(func(r *http.Request) error {
+			r.Header.Set("Authorization", "Bearer fake-token")
+			return nil
+		})),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	}, opts...)
+
+	tr, err := NewTranslator("my-project", nil, opts...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return tr
+}
+
+func TestTranslate(t *testing.T) {
+	expectedPath := "/v3/projects/my-project/locations/us-central1:translateText"
+	expectedRequest := translationRequest{
+		Contents:           []string{"Hello World!"},
+		MimeType:           "text/plain",
+		SourceLanguageCode: "en",
+		TargetLanguageCode: "de",
+		Model:              "projects/my-project/locations/us-central1/models/general/nmt",
+		GlossaryConfig: &glossaryConfig{
+			Glossary: "projects/my-project/locations/us-central1/glossaries/my-glossary",
+		},
+	}
+	expectedTranslation := "Hallo Welt!"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method. Got: %s, Want: POST", r.Method)
+		}
+
+		if r.URL.Path != expectedPath {
+			t.Fatalf("Unexpected request path. Got: %s, Want: %s", r.URL.Path, expectedPath)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("Unexpected content type: %s", r.Header.Get("Content-Type"))
+		}
+
+		actualRequest := translationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&actualRequest); err != nil {
+			t.Fatalf("Unexpected error decoding request: %s", err)
+		}
+
+		if !reflect.DeepEqual(actualRequest, expectedRequest) {
+			t.Fatalf("Unexpected request. Got: %+v, Want: %+v", actualRequest, expectedRequest)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"translations": [ { "translatedText": "Hallo!" } ],
+			"glossaryTranslations": [ { "translatedText": "%s" } ]
+		}`, expectedTranslation)
+	}))
+	defer server.Close()
+
+	tr := newTestTranslator(t, server, WithLocation("us-central1"), WithModel("general/nmt"), WithGlossary("my-glossary"))
+
+	actualTranslation, err := tr.Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation. Got: %s, Want: %s", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	expectedRequest := translationRequest{
+		Contents:           []string{"<b>Hello</b> World!"},
+		MimeType:           "text/html",
+		SourceLanguageCode: "en",
+		TargetLanguageCode: "de",
+		Model:              "projects/my-project/locations/us-central1/models/custom",
+		GlossaryConfig: &glossaryConfig{
+			Glossary: "projects/my-project/locations/us-central1/glossaries/other-glossary",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualRequest := translationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&actualRequest); err != nil {
+			t.Fatalf("Unexpected error decoding request: %s", err)
+		}
+
+		if !reflect.DeepEqual(actualRequest, expectedRequest) {
+			t.Fatalf("Unexpected request. Got: %+v, Want: %+v", actualRequest, expectedRequest)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "glossaryTranslations": [ { "translatedText": "<b>Hallo</b> Welt!" } ] }`)
+	}))
+	defer server.Close()
+
+	tr := newTestTranslator(t, server, WithLocation("us-central1"), WithModel("general/nmt"), WithGlossary("my-glossary"))
+
+	result, err := tr.(translator.OptionsTranslator).TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text: "<b>Hello</b> World!",
+		From: "en",
+		To:   "de",
+		TranslateOptions: translator.TranslateOptions{
+			Format:   translator.HTML,
+			Model:    "custom",
+			Glossary: "other-glossary",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if result.Text != "<b>Hallo</b> Welt!" {
+		t.Fatalf("Unexpected translation. Got: %s, Want: <b>Hallo</b> Welt!", result.Text)
+	}
+
+	_, err = tr.(translator.OptionsTranslator).TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             "Hello",
+		From:             "en",
+		To:               "de",
+		TranslateOptions: translator.TranslateOptions{Category: "tech"},
+	})
+
+	if !errors.Is(err, translator.ErrUnsupportedOption) {
+		t.Fatalf("Expected ErrUnsupportedOption. Got: %v", err)
+	}
+}
+
+func TestTranslateGlossaryWithoutSource(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCounter, 1)
+
+		request := translationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Unexpected error decoding request: %s", err)
+		}
+
+		if request.GlossaryConfig != nil {
+			t.Fatalf("Unexpected glossary without source language. Got: %+v", request.GlossaryConfig)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "translations": [ { "translatedText": "Hallo", "detectedLanguageCode": "en" } ] }`)
+	}))
+	defer server.Close()
+
+	tr := newTestTranslator(t, server, WithGlossary("my-glossary"))
+
+	if _, err := tr.Translate("Hello", "", "de"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	_, err := tr.(translator.OptionsTranslator).TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             "Hello",
+		To:               "de",
+		TranslateOptions: translator.TranslateOptions{Glossary: "other-glossary"},
+	})
+
+	if err == nil {
+		t.Fatal("Expected error for glossary without source language.")
+	}
+
+	if count := atomic.LoadInt32(&requestCounter); count != 1 {
+		t.Fatalf("Unexpected number of requests: %d. Expected: 1.", count)
+	}
+}
+
+func TestTranslateBatch(t *testing.T) {
+	texts := []string{"one", "two", "three"}
+	expectedTranslations := []string{"eins", "zwei", "drei"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := translationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Unexpected error decoding request: %s", err)
+		}
+
+		if !reflect.DeepEqual(request.Contents, texts) {
+			t.Fatalf("Unexpected contents. Got: %v, Want: %v", request.Contents, texts)
+		}
+
+		if request.Model != "" || request.GlossaryConfig != nil {
+			t.Fatalf("Unexpected model or glossary. Got: %s, %+v", request.Model, request.GlossaryConfig)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"translations": [
+			{ "translatedText": "eins" },
+			{ "translatedText": "zwei" },
+			{ "translatedText": "drei" }
+		]}`)
+	}))
+	defer server.Close()
+
+	tr := newTestTranslator(t, server).(translator.BatchTranslator)
+
+	actualTranslations, err := tr.TranslateBatch(texts, "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(actualTranslations, expectedTranslations) {
+		t.Fatalf("Unexpected translations. Got: %v, Want: %v", actualTranslations, expectedTranslations)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	expectedPath := "/v3/projects/my-project/locations/global:detectLanguage"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != expectedPath {
+			t.Fatalf("Unexpected request path. Got: %s, Want: %s", r.URL.Path, expectedPath)
+		}
+
+		request := detectionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Unexpected error decoding request: %s", err)
+		}
+
+		if request.Content != "Hallo Welt!" {
+			t.Fatalf("Unexpected content. Got: %s, Want: %s", request.Content, "Hallo Welt!")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"languages": [ { "languageCode": "de", "confidence": 0.98 } ]}`)
+	}))
+	defer server.Close()
+
+	actual, err := newTestTranslator(t, server).Detect("Hallo Welt!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual != "de" {
+		t.Fatalf("Unexpected language. Got: %s, Want: %s", actual, "de")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	expectedPath := "/v3/projects/my-project/locations/global/supportedLanguages"
+	expectedLanguages := []translator.Language{
+		{Code: "de", Name: "German", Tag: "de"},
+		{Code: "en", Name: "English", Tag: "en"},
+		{Code: "iw", Name: "Hebrew", Tag: "he"},
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "GET" {
+			t.Fatalf("Unexpected request method. Got: %s, Want: GET", r.Method)
+		}
+
+		if r.URL.Path != expectedPath {
+			t.Fatalf("Unexpected request path. Got: %s, Want: %s", r.URL.Path, expectedPath)
+		}
+
+		if r.URL.Query().Get("displayLanguageCode") != "en" {
+			t.Fatalf("Unexpected display language code: %s", r.URL.Query().Get("displayLanguageCode"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"languages": [
+			{ "languageCode": "de", "displayName": "German", "supportSource": true, "supportTarget": true },
+			{ "languageCode": "en", "displayName": "English", "supportSource": true, "supportTarget": true },
+			{ "languageCode": "iw", "displayName": "Hebrew", "supportSource": true, "supportTarget": true }
+		]}`)
+	}))
+	defer server.Close()
+
+	tr := newTestTranslator(t, server)
+
+	for i := 0; i < 2; i++ {
+		actualLanguages, err := tr.Languages()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actualLanguages, expectedLanguages) {
+			t.Fatalf("Unexpected languages. Got: %v, Want: %v", actualLanguages, expectedLanguages)
+		}
+	}
+
+	if requestCounter != 1 {
+		t.Fatalf("Unexpected number of requests. Got: %d, Want: %d", requestCounter, 1)
+	}
+}
+
+func TestTranslateRetriesResourceExhausted(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":429,"message":"Quota exceeded.","status":"RESOURCE_EXHAUSTED"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"translations": [ { "translatedText": "Hallo Welt!" } ]}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestTranslator(t, server).Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d, Want: %d", requestCounter, 2)
+	}
+}
+
+func TestTranslateContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("Unexpected request")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tr := newTestTranslator(t, server).(translator.ContextTranslator)
+
+	_, err := tr.TranslateContext(ctx, "Hello World!", "en", "de")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error. Got: %v, Want: %v", err, context.Canceled)
+	}
+}
+
+func TestNewTranslatorMissingProject(t *testing.T) {
+	_, err := NewTranslator("", []byte(`{"type": "service_account"}`))
+	if err == nil {
+		t.Fatal("Expected error for missing project ID")
+	}
+}
@@ -0,0 +1,133 @@
+package advanced
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+type languagesPayload struct {
+	Languages []struct {
+		LanguageCode  string
+		DisplayName   string
+		SupportSource bool
+		SupportTarget bool
+	}
+}
+
+type detectionRequest struct {
+	Content  string `json:"content"`
+	MimeType string `json:"mimeType"`
+}
+
+type detectionPayload struct {
+	Languages []struct {
+		LanguageCode string
+		Confidence   float64
+	}
+}
+
+type languageProvider interface {
+	languages(ctx context.Context) ([]translator.Language, error)
+	refresh(ctx context.Context) error
+	detect(ctx context.Context, text string) (string, error)
+}
+
+type concreteLanguageProvider struct {
+	router     *router
+	httpClient http.Client
+	catalog    *translator.Catalog
+}
+
+func newLanguageProvider(c http.Client, r *router, ttl time.Duration) *concreteLanguageProvider {
+	p := &concreteLanguageProvider{
+		router:     r,
+		httpClient: c,
+	}
+	p.catalog = translator.NewCatalog(ttl, p.fetchLanguages)
+	return p
+}
+
+func (p *concreteLanguageProvider) languages(ctx context.Context) ([]translator.Language, error) {
+	return p.catalog.Languages(ctx, "en")
+}
+
+func (p *concreteLanguageProvider) refresh(ctx context.Context) error {
+	return p.catalog.Refresh(ctx)
+}
+
+func (p *concreteLanguageProvider) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	resp, err := p.httpClient.SendRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s?displayLanguageCode=%s", p.router.supportedLanguagesURL(), url.QueryEscape(displayLocale)),
+		nil,
+		"application/json",
+	)
+
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &languagesPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*languagesPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	languages := make([]translator.Language, len(payload.Languages))
+	for i, l := range payload.Languages {
+		languages[i] = translator.Language{
+			Code: l.LanguageCode,
+			Name: l.DisplayName,
+			Tag:  codeMap.Tag(l.LanguageCode),
+		}
+	}
+
+	return languages, nil
+}
+
+func (p *concreteLanguageProvider) detect(ctx context.Context, text string) (string, error) {
+	body, err := json.Marshal(&detectionRequest{
+		Content:  text,
+		MimeType: "text/plain",
+	})
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+
+	resp, err := p.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, text),
+		"POST",
+		p.router.detectLanguageURL(),
+		bytes.NewReader(body),
+		"application/json",
+	)
+
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &detectionPayload{})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	payload, ok := result.(*detectionPayload)
+	if !ok || len(payload.Languages) == 0 {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	return payload.Languages[0].LanguageCode, nil
+}
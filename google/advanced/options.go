@@ -0,0 +1,141 @@
+package advanced
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+const defaultLocation = "global"
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	baseURL       string
+	location      string
+	model         string
+	glossary      string
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	clientOptions []http.ClientOption
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		baseURL:     baseURL,
+		location:    defaultLocation,
+		retryPolicy: http.DefaultRetryPolicy(),
+		catalogTTL:  translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.retryPolicy.Retryable == nil {
+		o.retryPolicy.Retryable = http.Retryable
+	}
+
+	return o
+}
+
+func (o *options) httpClient() http.Client {
+	clientOptions := append([]http.ClientOption{http.WithRetryPolicy(o.retryPolicy)}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithLocation sets the region that serves the requests, e.g. us-central1.
+// Custom models and glossaries are only available in the region they have
+// been created in. Defaults to global.
+func WithLocation(location string) Option {
+	return func(o *options) {
+		o.location = location
+	}
+}
+
+// WithModel makes the Translator use the given model, e.g. general/nmt or
+// the ID of a custom AutoML model. Fully qualified resource names starting
+// with projects/ are used as is.
+func WithModel(model string) Option {
+	return func(o *options) {
+		o.model = model
+	}
+}
+
+// WithGlossary makes the Translator apply the glossary with the given ID to
+// translations with a source language. Fully qualified resource names
+// starting with projects/ are used as is.
+func WithGlossary(glossary string) Option {
+	return func(o *options) {
+		o.glossary = glossary
+	}
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithBaseURL makes the Translator send requests to the given base URL
+// instead of https://translation.googleapis.com/v3/.
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		o.baseURL = url
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that obtains access
+// tokens for the service account. The credentials passed to NewTranslator
+// are not used in that case and may be nil, provided a project ID is given.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy. If the policy does not
+// specify a Retryable function, http.Retryable is used.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
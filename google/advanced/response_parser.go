@@ -0,0 +1,72 @@
+package advanced
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const provider = "google"
+
+type errorPayload struct {
+	Error struct {
+		Code    int
+		Message string
+		Status  string
+	}
+}
+
+var parseResponse = func(resp *http.Response, target interface{}) (interface{}, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(resp, body)
+	}
+
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return target, nil
+}
+
+// newAPIError classifies the error described by the given response body.
+// See https://cloud.google.com/apis/design/errors#handling_errors
+func newAPIError(resp *http.Response, body []byte) *translator.APIError {
+	err := _http.NewAPIError(provider, resp)
+
+	payload := &errorPayload{}
+	if json.Unmarshal(body, payload) != nil || payload.Error.Code == 0 {
+		err.Message = strings.TrimSpace(string(body))
+		return err
+	}
+
+	err.Code = strconv.Itoa(payload.Error.Code)
+	err.Reason = payload.Error.Status
+	err.Message = payload.Error.Message
+
+	switch err.Reason {
+	case "UNAUTHENTICATED", "PERMISSION_DENIED":
+		err.Err = translator.ErrUnauthorized
+	case "RESOURCE_EXHAUSTED":
+		err.Err = translator.ErrQuotaExceeded
+		err.Retryable = true
+	case "INVALID_ARGUMENT":
+		if strings.Contains(strings.ToLower(err.Message), "language") {
+			err.Err = translator.ErrUnsupportedLanguage
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,84 @@
+package advanced
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestParseResponseError(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		reason     string
+		sentinel   error
+		retryable  bool
+	}{
+		{401, `{"error":{"code":401,"message":"Request had invalid authentication credentials.","status":"UNAUTHENTICATED"}}`, "UNAUTHENTICATED", translator.ErrUnauthorized, false},
+		{403, `{"error":{"code":403,"message":"Cloud Translation API has not been used in project.","status":"PERMISSION_DENIED"}}`, "PERMISSION_DENIED", translator.ErrUnauthorized, false},
+		{429, `{"error":{"code":429,"message":"Quota exceeded.","status":"RESOURCE_EXHAUSTED"}}`, "RESOURCE_EXHAUSTED", translator.ErrQuotaExceeded, true},
+		{400, `{"error":{"code":400,"message":"Target language is invalid.","status":"INVALID_ARGUMENT"}}`, "INVALID_ARGUMENT", translator.ErrUnsupportedLanguage, false},
+		{400, `{"error":{"code":400,"message":"Empty contents.","status":"INVALID_ARGUMENT"}}`, "INVALID_ARGUMENT", nil, false},
+		{503, `{"error":{"code":503,"message":"The service is currently unavailable.","status":"UNAVAILABLE"}}`, "UNAVAILABLE", nil, true},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{
+			StatusCode: test.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+		}
+
+		_, err := parseResponse(resp, &translationPayload{})
+
+		apiErr := &translator.APIError{}
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Unexpected error type. Got: %T, Want: *translator.APIError", err)
+		}
+
+		if apiErr.Provider != provider {
+			t.Fatalf("Unexpected provider. Got: %s, Want: %s", apiErr.Provider, provider)
+		}
+
+		if apiErr.StatusCode != test.statusCode {
+			t.Fatalf("Unexpected status code. Got: %d, Want: %d", apiErr.StatusCode, test.statusCode)
+		}
+
+		if apiErr.Reason != test.reason {
+			t.Fatalf("Unexpected reason. Got: %s, Want: %s", apiErr.Reason, test.reason)
+		}
+
+		if apiErr.Err != test.sentinel {
+			t.Fatalf("Unexpected sentinel error. Got: %v, Want: %v", apiErr.Err, test.sentinel)
+		}
+
+		if apiErr.Retryable != test.retryable {
+			t.Fatalf("Unexpected retryable flag for %s. Got: %t, Want: %t", test.reason, apiErr.Retryable, test.retryable)
+		}
+	}
+}
+
+func TestParseResponseUnstructuredError(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Body:       ioutil.NopCloser(strings.NewReader("Bad Gateway\n")),
+	}
+
+	_, err := parseResponse(resp, &translationPayload{})
+
+	apiErr := &translator.APIError{}
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Unexpected error type. Got: %T, Want: *translator.APIError", err)
+	}
+
+	if apiErr.Message != "Bad Gateway" {
+		t.Fatalf("Unexpected message. Got: %s, Want: %s", apiErr.Message, "Bad Gateway")
+	}
+
+	if !apiErr.Retryable {
+		t.Fatal("Expected error to be retryable")
+	}
+}
@@ -0,0 +1,61 @@
+package advanced
+
+import (
+	"fmt"
+	"strings"
+)
+
+const baseURL = "https://translation.googleapis.com/v3/"
+
+type router struct {
+	parent                     string
+	translateTextEndpoint      string
+	detectLanguageEndpoint     string
+	supportedLanguagesEndpoint string
+}
+
+func newRouter(baseURL, projectID, location string) *router {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+
+	return &router{
+		parent:                     parent,
+		translateTextEndpoint:      baseURL + parent + ":translateText",
+		detectLanguageEndpoint:     baseURL + parent + ":detectLanguage",
+		supportedLanguagesEndpoint: baseURL + parent + "/supportedLanguages",
+	}
+}
+
+func (r *router) translateTextURL() string {
+	return r.translateTextEndpoint
+}
+
+func (r *router) detectLanguageURL() string {
+	return r.detectLanguageEndpoint
+}
+
+func (r *router) supportedLanguagesURL() string {
+	return r.supportedLanguagesEndpoint
+}
+
+// modelName returns the resource name of the given model, e.g.
+// "general/nmt" or the ID of a custom AutoML model. Full resource
+// names are returned as is.
+func (r *router) modelName(model string) string {
+	if model == "" || strings.HasPrefix(model, "projects/") {
+		return model
+	}
+	return r.parent + "/models/" + model
+}
+
+// glossaryName returns the resource name of the glossary with the
+// given ID. Full resource names are returned as is.
+func (r *router) glossaryName(glossary string) string {
+	if glossary == "" || strings.HasPrefix(glossary, "projects/") {
+		return glossary
+	}
+	return r.parent + "/glossaries/" + glossary
+}
@@ -0,0 +1,46 @@
+package advanced
+
+import "testing"
+
+func TestNewRouter(t *testing.T) {
+	for _, base := range []string{"https://example.com/v3", "https://example.com/v3/"} {
+		r := newRouter(base, "my-project", "us-central1")
+
+		expected := "https://example.com/v3/projects/my-project/locations/us-central1:translateText"
+		if r.translateTextURL() != expected {
+			t.Fatalf("Unexpected translateText URL. Got: %s, Want: %s", r.translateTextURL(), expected)
+		}
+
+		expected = "https://example.com/v3/projects/my-project/locations/us-central1:detectLanguage"
+		if r.detectLanguageURL() != expected {
+			t.Fatalf("Unexpected detectLanguage URL. Got: %s, Want: %s", r.detectLanguageURL(), expected)
+		}
+
+		expected = "https://example.com/v3/projects/my-project/locations/us-central1/supportedLanguages"
+		if r.supportedLanguagesURL() != expected {
+			t.Fatalf("Unexpected supportedLanguages URL. Got: %s, Want: %s", r.supportedLanguagesURL(), expected)
+		}
+	}
+}
+
+func TestRouterResourceNames(t *testing.T) {
+	r := newRouter(baseURL, "my-project", "global")
+
+	tests := []struct {
+		actual   string
+		expected string
+	}{
+		{r.modelName(""), ""},
+		{r.modelName("general/nmt"), "projects/my-project/locations/global/models/general/nmt"},
+		{r.modelName("projects/other/locations/us-central1/models/TRL123"), "projects/other/locations/us-central1/models/TRL123"},
+		{r.glossaryName(""), ""},
+		{r.glossaryName("my-glossary"), "projects/my-project/locations/global/glossaries/my-glossary"},
+		{r.glossaryName("projects/other/locations/us-central1/glossaries/g"), "projects/other/locations/us-central1/glossaries/g"},
+	}
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Fatalf("Unexpected resource name. Got: %s, Want: %s", test.actual, test.expected)
+		}
+	}
+}
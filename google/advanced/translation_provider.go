@@ -0,0 +1,177 @@
+package advanced
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// Google accepts at most 1024 texts per translation request and
+// recommends to keep requests below 30000 characters.
+const (
+	maxBatchTexts = 1024
+	maxBatchChars = 30000
+)
+
+type glossaryConfig struct {
+	Glossary string `json:"glossary"`
+}
+
+type translationRequest struct {
+	Contents           []string        `json:"contents"`
+	MimeType           string          `json:"mimeType"`
+	SourceLanguageCode string          `json:"sourceLanguageCode,omitempty"`
+	TargetLanguageCode string          `json:"targetLanguageCode"`
+	Model              string          `json:"model,omitempty"`
+	GlossaryConfig     *glossaryConfig `json:"glossaryConfig,omitempty"`
+}
+
+type translation struct {
+	TranslatedText       string
+	Model                string
+	DetectedLanguageCode string
+}
+
+type translationPayload struct {
+	Translations         []translation
+	GlossaryTranslations []translation
+}
+
+type translationProvider interface {
+	translate(ctx context.Context, text, from, to string) (string, error)
+	translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error)
+	translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error)
+}
+
+type concreteTranslationProvider struct {
+	httpClient http.Client
+	router     *router
+	model      string
+	glossary   string
+}
+
+func newTranslationProvider(c http.Client, r *router, model, glossary string) *concreteTranslationProvider {
+	return &concreteTranslationProvider{
+		httpClient: c,
+		router:     r,
+		model:      model,
+		glossary:   glossary,
+	}
+}
+
+func (t *concreteTranslationProvider) translate(ctx context.Context, text, from, to string) (string, error) {
+	return t.translateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+}
+
+// translateWithOptions requests the translation of the given text. The API
+// supports the Format, Model, and Glossary options, which take precedence
+// over the model and glossary the translator has been configured with. The
+// glossary requires a source language.
+func (t *concreteTranslationProvider) translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error) {
+	if err := opts.Validate(provider, "Format", "Model", "Glossary"); err != nil {
+		return "", err
+	}
+
+	if opts.Glossary != "" && from == "" {
+		return "", tracerr.Error("Glossaries require a source language.")
+	}
+
+	translations, err := t.send(ctx, []string{text}, from, to, opts)
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	return translations[0].TranslatedText, nil
+}
+
+func (t *concreteTranslationProvider) translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	result := make([]string, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		translations, err := t.send(ctx, batch, from, to, translator.TranslateOptions{})
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		for _, translation := range translations {
+			result = append(result, translation.TranslatedText)
+		}
+	}
+
+	return result, nil
+}
+
+// send requests the translation of the given texts and returns exactly one
+// translation per text. Translations that make use of the glossary take
+// precedence. The API rejects glossaries without a source language, hence
+// the glossary is omitted if from is empty.
+func (t *concreteTranslationProvider) send(ctx context.Context, texts []string, from, to string, opts translator.TranslateOptions) ([]translation, error) {
+	model, glossary := t.model, t.glossary
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	if opts.Glossary != "" {
+		glossary = opts.Glossary
+	}
+
+	request := &translationRequest{
+		Contents:           texts,
+		MimeType:           "text/plain",
+		SourceLanguageCode: from,
+		TargetLanguageCode: to,
+		Model:              t.router.modelName(model),
+	}
+
+	if opts.Format == translator.HTML {
+		request.MimeType = "text/html"
+	}
+
+	if glossary != "" && from != "" {
+		request.GlossaryConfig = &glossaryConfig{
+			Glossary: t.router.glossaryName(glossary),
+		}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	resp, err := t.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, texts...),
+		"POST",
+		t.router.translateTextURL(),
+		bytes.NewReader(body),
+		"application/json",
+	)
+
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &translationPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*translationPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	translations := payload.Translations
+	if len(payload.GlossaryTranslations) > 0 {
+		translations = payload.GlossaryTranslations
+	}
+
+	if len(translations) != len(texts) {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return translations, nil
+}
@@ -1,31 +1,142 @@
 package google
 
-import "github.com/st3v/translator"
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/st3v/translator"
+)
 
 type api struct {
-	lp languageProvider
-	tp translationProvider
+	lp            languageProvider
+	tp            translationProvider
+	minConfidence float64
 }
 
 // NewTranslator instantiates a new Translator for Google's Translate API.
-func NewTranslator(apiKey string) translator.Translator {
-	authenticator := newAuthenticator(apiKey)
-	router := newRouter()
+// The returned Translator also implements translator.ContextTranslator,
+// translator.BatchTranslator, translator.OptionsTranslator,
+// translator.LocalizedTranslator, translator.DetailedDetector,
+// translator.BatchDetector, and
+// translator.LanguageRefresher.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslator(apiKey string, opts ...Option) translator.Translator {
+	options := newOptions(apiKey, opts)
+	httpClient := options.httpClient()
+	router := newRouter(options.baseURL)
 
 	return &api{
-		lp: newLanguageProvider(authenticator, router),
-		tp: newTranslationProvider(authenticator, router),
+		lp:            newLanguageProvider(httpClient, router, options.catalogTTL),
+		tp:            newTranslationProvider(httpClient, router),
+		minConfidence: options.minConfidence,
 	}
 }
 
 func (a *api) Languages() ([]translator.Language, error) {
-	return a.lp.languages()
+	return a.LanguagesContext(context.Background())
 }
 
 func (a *api) Detect(text string) (string, error) {
-	return a.lp.detect(text)
+	return a.DetectContext(context.Background(), text)
 }
 
 func (a *api) Translate(text, from, to string) (string, error) {
-	return a.tp.translate(text, from, to)
+	return a.TranslateContext(context.Background(), text, from, to)
+}
+
+func (a *api) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return a.lp.languages(ctx, "en")
+}
+
+func (a *api) LanguagesIn(displayLocale string) ([]translator.Language, error) {
+	return a.LanguagesInContext(context.Background(), displayLocale)
+}
+
+func (a *api) LanguagesInContext(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return a.lp.languages(ctx, displayLocale)
+}
+
+func (a *api) DetectContext(ctx context.Context, text string) (string, error) {
+	return a.lp.detect(ctx, text)
+}
+
+func (a *api) RefreshLanguages(ctx context.Context) error {
+	return a.lp.refresh(ctx)
+}
+
+func (a *api) DetectDetailed(text string) ([]translator.Detection, error) {
+	return a.DetectDetailedContext(context.Background(), text)
+}
+
+func (a *api) DetectDetailedContext(ctx context.Context, text string) ([]translator.Detection, error) {
+	return a.lp.detectDetailed(ctx, text)
+}
+
+func (a *api) DetectBatch(texts []string) ([]translator.Detection, error) {
+	return a.DetectBatchContext(context.Background(), texts)
+}
+
+func (a *api) DetectBatchContext(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	return a.lp.detectBatch(ctx, texts)
+}
+
+func (a *api) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	result, err := a.TranslateWithOptions(ctx, translator.TranslateRequest{Text: text, From: from, To: to})
+	return result.Text, err
+}
+
+// TranslateWithOptions implements translator.OptionsTranslator. The source
+// language is detected if the request does not specify one. Google bills
+// every character of the source text, including markup.
+func (a *api) TranslateWithOptions(ctx context.Context, req translator.TranslateRequest) (translator.TranslateResult, error) {
+	start := time.Now()
+
+	from := codeMap.Code(req.From)
+	if from == "" && a.minConfidence > 0 {
+		var err error
+		if from, err = a.detectSource(ctx, req.Text); err != nil {
+			return translator.TranslateResult{}, err
+		}
+	}
+
+	result, err := a.tp.translate(ctx, req.Text, from, codeMap.Code(req.To), req.TranslateOptions)
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	if req.From == "" && result.DetectedLanguage == "" {
+		result.DetectedLanguage = from
+	}
+
+	result.Provider = provider
+	result.BilledCharacters = utf8.RuneCountInString(req.Text)
+	result.Latency = time.Since(start)
+
+	return result, nil
+}
+
+// detectSource returns the language of the given text as detected by the
+// detection API, or translator.ErrLowConfidence if the confidence of the
+// detection is below the configured minimum.
+func (a *api) detectSource(ctx context.Context, text string) (string, error) {
+	detections, err := a.lp.detectDetailed(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	if detections[0].Confidence < a.minConfidence {
+		return "", translator.ErrLowConfidence
+	}
+
+	return detections[0].Language, nil
+}
+
+func (a *api) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return a.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+func (a *api) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return a.tp.translateBatch(ctx, texts, codeMap.Code(from), codeMap.Code(to))
 }
@@ -1,24 +1,231 @@
 package google
 
-import "github.com/st3v/translator"
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestAPITranslateCodeMap(t *testing.T) {
+	expectedTranslation := "你好"
+
+	api := &api{
+		tp: &mockTranslationProvider{
+			translateFunc: func(text, from, to string) (string, error) {
+				if from != "iw" {
+					t.Fatalf("Unexpected source language. Got: %s. Want: iw.", from)
+				}
+				if to != "zh-TW" {
+					t.Fatalf("Unexpected target language. Got: %s. Want: zh-TW.", to)
+				}
+				return expectedTranslation, nil
+			},
+		},
+	}
+
+	actualTranslation, err := api.Translate("שלום", "he", "zh-Hant")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestAPITranslateHTML(t *testing.T) {
+	tp := &mockTranslationProvider{
+		translateFunc: func(text, from, to string) (string, error) {
+			if from != "iw" {
+				t.Fatalf("Unexpected source language. Got: %s. Want: iw.", from)
+			}
+			return "<b>Hello</b>", nil
+		},
+	}
+
+	api := &api{tp: tp}
+
+	if _, err := api.Translate("שלום", "he", "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if tp.opts.Format != translator.Text {
+		t.Fatalf("Unexpected format. Got: %s. Want: %s.", tp.opts.Format, translator.Text)
+	}
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             "<b>שלום</b>",
+		From:             "he",
+		To:               "en",
+		TranslateOptions: translator.TranslateOptions{Format: translator.HTML},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "<b>Hello</b>" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: <b>Hello</b>.", result.Text)
+	}
+
+	if tp.opts.Format != translator.HTML {
+		t.Fatalf("Unexpected format. Got: %s. Want: %s.", tp.opts.Format, translator.HTML)
+	}
+}
+
+func TestAPITranslateWithOptions(t *testing.T) {
+	tp := &mockTranslationProvider{
+		translateFunc: func(text, from, to string) (string, error) {
+			if from != "iw" || to != "zh-TW" {
+				t.Fatalf("Unexpected languages. Got: %s, %s. Want: iw, zh-TW.", from, to)
+			}
+			return "你好", nil
+		},
+	}
+
+	api := &api{tp: tp}
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             "שלום",
+		From:             "he",
+		To:               "zh-Hant",
+		TranslateOptions: translator.TranslateOptions{Model: "nmt"},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "你好" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: 你好.", result.Text)
+	}
+
+	if tp.opts.Model != "nmt" {
+		t.Fatalf("Unexpected model. Got: %s. Want: nmt.", tp.opts.Model)
+	}
+
+	var _ translator.OptionsTranslator = api
+}
 
 type mockLanguageProvider struct {
-	languagesFunc func() ([]translator.Language, error)
+	languagesFunc func(displayLocale string) ([]translator.Language, error)
 	detectFunc    func(text string) (string, error)
+	detailedFunc  func(text string) ([]translator.Detection, error)
+	batchFunc     func(texts []string) ([]translator.Detection, error)
+}
+
+func (m *mockLanguageProvider) languages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return m.languagesFunc(displayLocale)
 }
 
-func (m *mockLanguageProvider) languages() ([]translator.Language, error) {
-	return m.languagesFunc()
+func (m *mockLanguageProvider) refresh(ctx context.Context) error {
+	return nil
 }
 
-func (m *mockLanguageProvider) detect(text string) (string, error) {
+func (m *mockLanguageProvider) detect(ctx context.Context, text string) (string, error) {
 	return m.detectFunc(text)
 }
 
+func (m *mockLanguageProvider) detectDetailed(ctx context.Context, text string) ([]translator.Detection, error) {
+	return m.detailedFunc(text)
+}
+
+func (m *mockLanguageProvider) detectBatch(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	return m.batchFunc(texts)
+}
+
 type mockTranslationProvider struct {
-	translateFunc func(text, from, to string) (string, error)
+	translateFunc      func(text, from, to string) (string, error)
+	translateBatchFunc func(texts []string, from, to string) ([]string, error)
+	opts               translator.TranslateOptions
+	detectedLanguage   string
+}
+
+func (m *mockTranslationProvider) translate(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error) {
+	m.opts = opts
+	translation, err := m.translateFunc(text, from, to)
+	return translator.TranslateResult{Text: translation, DetectedLanguage: m.detectedLanguage}, err
+}
+
+func (m *mockTranslationProvider) translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return m.translateBatchFunc(texts, from, to)
 }
 
-func (m *mockTranslationProvider) translate(text, from, to string) (string, error) {
-	return m.translateFunc(text, from, to)
+func TestAPITranslateResult(t *testing.T) {
+	api := &api{
+		tp: &mockTranslationProvider{
+			translateFunc: func(text, from, to string) (string, error) {
+				if from != "" {
+					t.Fatalf("Unexpected source language. Got: %s. Want: empty.", from)
+				}
+				return "Hello", nil
+			},
+			detectedLanguage: "iw",
+		},
+	}
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "שלום", From: "", To: "en"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: Hello.", result.Text)
+	}
+
+	if result.DetectedLanguage != "iw" {
+		t.Fatalf("Unexpected detected language. Got: %s. Want: iw.", result.DetectedLanguage)
+	}
+
+	if result.Provider != "google" {
+		t.Fatalf("Unexpected provider. Got: %s. Want: google.", result.Provider)
+	}
+
+	if result.BilledCharacters != 4 {
+		t.Fatalf("Unexpected billed characters. Got: %d. Want: 4.", result.BilledCharacters)
+	}
+
+	if result.Latency <= 0 {
+		t.Fatalf("Unexpected latency: %s", result.Latency)
+	}
+}
+
+func TestAPITranslateMinConfidence(t *testing.T) {
+	confidence := 0.4
+
+	tp := &mockTranslationProvider{
+		translateFunc: func(text, from, to string) (string, error) {
+			if from != "iw" {
+				t.Fatalf("Unexpected source language. Got: %s. Want: iw.", from)
+			}
+			return "Hello", nil
+		},
+	}
+
+	api := &api{
+		lp: &mockLanguageProvider{
+			detailedFunc: func(text string) ([]translator.Detection, error) {
+				return []translator.Detection{{Language: "iw", Confidence: confidence}}, nil
+			},
+		},
+		tp:            tp,
+		minConfidence: 0.5,
+	}
+
+	_, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "שלום", From: "", To: "en"})
+	if !errors.Is(err, translator.ErrLowConfidence) {
+		t.Fatalf("Expected ErrLowConfidence. Got: %v", err)
+	}
+
+	confidence = 0.9
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "שלום", From: "", To: "en"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" || result.DetectedLanguage != "iw" {
+		t.Fatalf("Unexpected result. Got: %+v. Want: Hello from iw.", result)
+	}
 }
@@ -0,0 +1,13 @@
+package auth
+
+import "time"
+
+type accessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (t *accessToken) expired() bool {
+	// be conservative and expire a minute early
+	return t.ExpiresAt.Before(time.Now().Add(time.Minute))
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const (
+	provider = "google"
+
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	tokenLifetime      = time.Hour
+)
+
+// The AccessTokenProvider obtains OAuth2 access tokens for Google's API endpoints.
+type AccessTokenProvider interface {
+	RefreshToken(context.Context, *accessToken) error
+}
+
+type accessTokenProvider struct {
+	account *ServiceAccount
+	key     *rsa.PrivateKey
+	scope   string
+}
+
+func newAccessTokenProvider(account *ServiceAccount, scope string) (AccessTokenProvider, error) {
+	key, err := account.rsaPrivateKey()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return &accessTokenProvider{
+		account: account,
+		key:     key,
+		scope:   scope,
+	}, nil
+}
+
+type tokenPayload struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+type tokenErrorPayload struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// RefreshToken exchanges a JWT signed with the service account's private key
+// for an access token. See https://developers.google.com/identity/protocols/oauth2/service-account.
+func (p *accessTokenProvider) RefreshToken(ctx context.Context, token *accessToken) error {
+	assertion, err := p.assertion(time.Now())
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", jwtBearerGrantType)
+	params.Set("assertion", assertion)
+
+	req, err := http.NewRequest("POST", p.account.TokenURI, strings.NewReader(params.Encode()))
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	client := new(http.Client)
+
+	response, err := client.Do(req)
+	if err != nil {
+		return _http.WrapError(err)
+	}
+
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		apiErr := _http.NewAPIError(provider, response)
+		payload := &tokenErrorPayload{}
+		if json.Unmarshal(body, payload) == nil && payload.Error != "" {
+			apiErr.Code = payload.Error
+			apiErr.Message = payload.ErrorDescription
+		}
+		if response.StatusCode == http.StatusBadRequest || response.StatusCode == http.StatusUnauthorized {
+			apiErr.Err = translator.ErrUnauthorized
+		}
+		return apiErr
+	}
+
+	payload := &tokenPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	token.Token = payload.AccessToken
+	token.ExpiresAt = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+
+	return nil
+}
+
+// assertion returns a JWT signed with RS256 that asserts the identity of
+// the service account.
+func (p *accessTokenProvider) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": p.account.PrivateKeyID,
+	})
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   p.account.ClientEmail,
+		"scope": p.scope,
+		"aud":   p.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenLifetime).Unix(),
+	})
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", tracerr.Wrap(err)
+	}
+
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+)
+
+// Make sure the access token provider sends a properly signed JWT to the
+// token endpoint and is able to generate a valid access token from the
+// server's response.
+func TestAccessTokenProviderRefreshToken(t *testing.T) {
+	key := newMockPrivateKey(t)
+	expectedToken := "some-token"
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if have, want := r.FormValue("grant_type"), jwtBearerGrantType; have != want {
+			t.Fatalf("Unexpected grant_type: want %q, have %q.", want, have)
+		}
+
+		claims := verifyJWT(t, r.FormValue("assertion"), &key.PublicKey)
+
+		if have, want := claims["iss"], "translator@my-project.iam.gserviceaccount.com"; have != want {
+			t.Fatalf("Unexpected iss claim: want %q, have %q.", want, have)
+		}
+
+		if have, want := claims["aud"], server.URL; have != want {
+			t.Fatalf("Unexpected aud claim: want %q, have %q.", want, have)
+		}
+
+		if have, want := claims["scope"], CloudTranslationScope; have != want {
+			t.Fatalf("Unexpected scope claim: want %q, have %q.", want, have)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "%s", "expires_in": 3599, "token_type": "Bearer"}`, expectedToken)
+	}))
+	defer server.Close()
+
+	account, err := ParseServiceAccount(newMockCredentials(t, key, server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	accessTokenProvider, err := newAccessTokenProvider(account, CloudTranslationScope)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	actualToken := new(accessToken)
+	if err := accessTokenProvider.RefreshToken(context.Background(), actualToken); err != nil {
+		t.Fatalf("Unexpected error returned by RefreshToken: %v", err.Error())
+	}
+
+	if have, want := actualToken.Token, expectedToken; have != want {
+		t.Fatalf("Unexpected Token: want %q, have %q.", want, have)
+	}
+
+	if s := time.Until(actualToken.ExpiresAt).Seconds(); s < 3597 || s > 3599 {
+		t.Fatalf("Unexpected ExpiresAt %s for access token generated from http response.", actualToken.ExpiresAt)
+	}
+}
+
+// Make sure a rejected assertion results in an unauthorized API error.
+func TestAccessTokenProviderRefreshTokenUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`)
+	}))
+	defer server.Close()
+
+	account, err := ParseServiceAccount(newMockCredentials(t, newMockPrivateKey(t), server.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	accessTokenProvider, err := newAccessTokenProvider(account, CloudTranslationScope)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	err = accessTokenProvider.RefreshToken(context.Background(), new(accessToken))
+	if !errors.Is(err, translator.ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized. Got: %v", err)
+	}
+
+	var apiErr *translator.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError. Got: %#v", err)
+	}
+
+	if apiErr.Code != "invalid_grant" || apiErr.Message != "Invalid JWT Signature." {
+		t.Fatalf("Unexpected APIError: %#v", apiErr)
+	}
+}
+
+// verifyJWT verifies the RS256 signature of the given JWT and returns its claims.
+func verifyJWT(t *testing.T, jwt string, key *rsa.PublicKey) map[string]interface{} {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Invalid JWT: %s", jwt)
+	}
+
+	header := map[string]string{}
+	decodeJWTPart(t, parts[0], &header)
+	if header["alg"] != "RS256" || header["kid"] != "my-key-id" {
+		t.Fatalf("Unexpected JWT header: %v", header)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("Unexpected error decoding JWT signature: %s", err.Error())
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("Invalid JWT signature: %s", err.Error())
+	}
+
+	claims := map[string]interface{}{}
+	decodeJWTPart(t, parts[1], &claims)
+	return claims
+}
+
+func decodeJWTPart(t *testing.T, part string, target interface{}) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding JWT: %s", err.Error())
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		t.Fatalf("Unexpected error unmarshalling JWT: %s", err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/st3v/tracerr"
+	_http "github.com/st3v/translator/http"
+)
+
+// CloudTranslationScope is the OAuth2 scope required by the Cloud Translation API.
+const CloudTranslationScope = "https://www.googleapis.com/auth/cloud-translation"
+
+type authenticator struct {
+	accessTokenProvider AccessTokenProvider
+	accessTokenChan     chan *accessToken
+}
+
+// NewServiceAccountAuthenticator returns an authenticator that authorizes
+// requests to Google API endpoints with OAuth2 access tokens for the
+// service account described by the given JSON key file. Tokens are
+// requested for the given scope and renewed shortly before they expire.
+func NewServiceAccountAuthenticator(credentials []byte, scope string) (_http.Authenticator, error) {
+	account, err := ParseServiceAccount(credentials)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	provider, err := newAccessTokenProvider(account, scope)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	// make buffered accessToken channel and pre-fill it with an expired token
+	tokenChan := make(chan *accessToken, 1)
+	tokenChan <- new(accessToken)
+
+	return &authenticator{
+		accessTokenProvider: provider,
+		accessTokenChan:     tokenChan,
+	}, nil
+}
+
+func (a *authenticator) Authenticate(request *http.Request) error {
+	authToken, err := a.authToken(request.Context())
+	if err != nil {
+		return _http.WrapError(err)
+	}
+
+	request.Header.Set("Authorization", authToken)
+	return nil
+}
+
+func (a *authenticator) authToken(ctx context.Context) (string, error) {
+	// grab the token, unless the context is done before another caller
+	// finished refreshing it
+	var token *accessToken
+	select {
+	case token = <-a.accessTokenChan:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	// make sure it's valid, otherwise request a new one
+	if token.expired() {
+		err := a.accessTokenProvider.RefreshToken(ctx, token)
+		if err != nil {
+			// put the stale token back, the next caller will try to refresh it again
+			a.accessTokenChan <- token
+			return "", _http.WrapError(err)
+		}
+	}
+
+	// put the token back on the channel
+	a.accessTokenChan <- token
+
+	// return authToken
+	return "Bearer " + token.Token, nil
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Make sure the authenticator authorizes requests with a cached access token.
+func TestAuthenticatorAuthenticate(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "some-token", "expires_in": 3599, "token_type": "Bearer"}`)
+	}))
+	defer server.Close()
+
+	authenticator, err := NewServiceAccountAuthenticator(
+		newMockCredentials(t, newMockPrivateKey(t), server.URL),
+		CloudTranslationScope,
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	for i := 0; i < 3; i++ {
+		r, err := http.NewRequest("GET", "http://foo.bar", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error when getting new request: %s", err.Error())
+		}
+
+		if err := authenticator.Authenticate(r); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if have, want := r.Header.Get("Authorization"), "Bearer some-token"; have != want {
+			t.Fatalf("Unexpected authorization header: want %q, have %q.", want, have)
+		}
+	}
+
+	if requestCounter != 1 {
+		t.Fatalf("Expected exactly 1 token request but counted %d.", requestCounter)
+	}
+}
+
+// Make sure requests waiting for another request's token refresh can be
+// cancelled.
+func TestAuthenticatorAuthenticateCancelled(t *testing.T) {
+	refreshing := make(chan struct{})
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(refreshing)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "some-token", "expires_in": 3599, "token_type": "Bearer"}`)
+	}))
+	defer server.Close()
+
+	authenticator, err := NewServiceAccountAuthenticator(
+		newMockCredentials(t, newMockPrivateKey(t), server.URL),
+		CloudTranslationScope,
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	errChan := make(chan error)
+	go func() {
+		r, err := http.NewRequest("GET", "http://foo.bar", nil)
+		if err == nil {
+			err = authenticator.Authenticate(r)
+		}
+		errChan <- err
+	}()
+
+	<-refreshing
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := http.NewRequest("GET", "http://foo.bar", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error when getting new request: %s", err.Error())
+	}
+
+	if err := authenticator.Authenticate(r.WithContext(ctx)); err != context.Canceled {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, context.Canceled)
+	}
+
+	close(release)
+
+	if err := <-errChan; err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestNewServiceAccountAuthenticatorInvalidKey(t *testing.T) {
+	credentials := `{"type": "service_account", "client_email": "foo", "private_key": "bar"}`
+
+	if _, err := NewServiceAccountAuthenticator([]byte(credentials), CloudTranslationScope); err == nil {
+		t.Fatal("Expected error but got none.")
+	}
+}
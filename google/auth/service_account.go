@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/st3v/tracerr"
+)
+
+const defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+// The ServiceAccount struct holds the relevant fields of a JSON key file
+// for a Google Cloud service account.
+type ServiceAccount struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ParseServiceAccount parses the given JSON key file of a service account.
+func ParseServiceAccount(credentials []byte) (*ServiceAccount, error) {
+	account := &ServiceAccount{}
+	if err := json.Unmarshal(credentials, account); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	if account.Type != "service_account" {
+		return nil, tracerr.Errorf("Unexpected credentials type: %q", account.Type)
+	}
+
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, tracerr.Error("Credentials lack client_email or private_key.")
+	}
+
+	if account.TokenURI == "" {
+		account.TokenURI = defaultTokenURI
+	}
+
+	return account, nil
+}
+
+func (a *ServiceAccount) rsaPrivateKey() (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(a.PrivateKey))
+	if block == nil {
+		return nil, tracerr.Error("Invalid private key: no PEM data found.")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, tracerr.Error("Invalid private key: not an RSA key.")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+)
+
+func TestParseServiceAccount(t *testing.T) {
+	key := newMockPrivateKey(t)
+	credentials := newMockCredentials(t, key, "")
+
+	account, err := ParseServiceAccount(credentials)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if have, want := account.ClientEmail, "translator@my-project.iam.gserviceaccount.com"; have != want {
+		t.Fatalf("Unexpected client email: want %q, have %q.", want, have)
+	}
+
+	if have, want := account.ProjectID, "my-project"; have != want {
+		t.Fatalf("Unexpected project ID: want %q, have %q.", want, have)
+	}
+
+	if have, want := account.TokenURI, defaultTokenURI; have != want {
+		t.Fatalf("Unexpected token URI: want %q, have %q.", want, have)
+	}
+
+	parsedKey, err := account.rsaPrivateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error parsing private key: %s", err.Error())
+	}
+
+	if parsedKey.N.Cmp(key.N) != 0 || parsedKey.D.Cmp(key.D) != 0 {
+		t.Fatal("Parsed private key does not match.")
+	}
+}
+
+func TestParseServiceAccountInvalid(t *testing.T) {
+	for _, credentials := range []string{
+		`not json`,
+		`{"type": "authorized_user", "client_email": "foo", "private_key": "bar"}`,
+		`{"type": "service_account", "private_key": "bar"}`,
+	} {
+		if _, err := ParseServiceAccount([]byte(credentials)); err == nil {
+			t.Errorf("Expected error for credentials %s but got none.", credentials)
+		}
+	}
+}
+
+func newMockPrivateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Unexpected error generating private key: %s", err.Error())
+	}
+	return key
+}
+
+func newMockCredentials(t *testing.T, key *rsa.PrivateKey, tokenURI string) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error marshalling private key: %s", err.Error())
+	}
+
+	credentials, err := json.Marshal(ServiceAccount{
+		Type:         "service_account",
+		ProjectID:    "my-project",
+		PrivateKeyID: "my-key-id",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		ClientEmail:  "translator@my-project.iam.gserviceaccount.com",
+		TokenURI:     tokenURI,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error marshalling credentials: %s", err.Error())
+	}
+
+	return credentials
+}
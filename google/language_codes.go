@@ -0,0 +1,14 @@
+package google
+
+import "github.com/st3v/translator"
+
+// codeMap maps canonical BCP 47 tags to the language codes of Google's API
+// where the two differ.
+var codeMap = translator.CodeMap{
+	"fil":     "tl",
+	"he":      "iw",
+	"jv":      "jw",
+	"nb":      "no",
+	"zh-Hans": "zh-CN",
+	"zh-Hant": "zh-TW",
+}
@@ -1,8 +1,12 @@
 package google
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/st3v/tracerr"
 	"github.com/st3v/translator"
@@ -18,93 +22,177 @@ type languagesPayload struct {
 	}
 }
 
+type detectionCandidate struct {
+	Language   string
+	IsReliable bool
+	Confidence float64
+}
+
 type detectionPayload struct {
 	Data struct {
-		Detections [][]struct {
-			Language string
-		}
+		Detections [][]detectionCandidate
 	}
 }
 
 type languageProvider interface {
-	languages() ([]translator.Language, error)
-	detect(text string) (string, error)
+	languages(ctx context.Context, displayLocale string) ([]translator.Language, error)
+	refresh(ctx context.Context) error
+	detect(ctx context.Context, text string) (string, error)
+	detectDetailed(ctx context.Context, text string) ([]translator.Detection, error)
+	detectBatch(ctx context.Context, texts []string) ([]translator.Detection, error)
 }
 
 type concreteLanguageProvider struct {
-	router        *router
-	authenticator http.Authenticator
-	catalog       []translator.Language
+	router     *router
+	httpClient http.Client
+	catalog    *translator.Catalog
 }
 
-func newLanguageProvider(a http.Authenticator, r *router) *concreteLanguageProvider {
-	return &concreteLanguageProvider{
-		router:        r,
-		authenticator: a,
-		catalog:       nil,
+func newLanguageProvider(c http.Client, r *router, ttl time.Duration) *concreteLanguageProvider {
+	p := &concreteLanguageProvider{
+		router:     r,
+		httpClient: c,
 	}
+	p.catalog = translator.NewCatalog(ttl, p.fetchLanguages)
+	return p
 }
 
-func (p *concreteLanguageProvider) languages() ([]translator.Language, error) {
-	if p.catalog == nil {
-		httpClient := http.NewClient(p.authenticator)
+func (p *concreteLanguageProvider) languages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return p.catalog.Languages(ctx, codeMap.Code(displayLocale))
+}
 
-		resp, err := httpClient.SendRequest(
-			"GET",
-			fmt.Sprintf("%s?target=en", p.router.languagesURL()),
-			nil,
-			"text/plain",
-		)
+func (p *concreteLanguageProvider) refresh(ctx context.Context) error {
+	return p.catalog.Refresh(ctx)
+}
 
-		if err != nil {
-			return nil, tracerr.Wrap(err)
-		}
+func (p *concreteLanguageProvider) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	resp, err := p.httpClient.SendRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s?target=%s", p.router.languagesURL(), url.QueryEscape(displayLocale)),
+		nil,
+		"text/plain",
+	)
 
-		result, err := parseResponse(resp, &languagesPayload{})
-		if err != nil {
-			return nil, tracerr.Wrap(err)
-		}
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
 
-		payload, ok := result.(*languagesPayload)
-		if !ok {
-			return nil, tracerr.Error("Invalid response.")
-		}
+	result, err := parseResponse(resp, &languagesPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
 
-		p.catalog = make([]translator.Language, len(payload.Data.Languages))
-		for i, l := range payload.Data.Languages {
-			p.catalog[i] = translator.Language{
-				Code: l.Language,
-				Name: l.Name,
-			}
+	payload, ok := result.(*languagesPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	languages := make([]translator.Language, len(payload.Data.Languages))
+	for i, l := range payload.Data.Languages {
+		languages[i] = translator.Language{
+			Code: l.Language,
+			Name: l.Name,
+			Tag:  codeMap.Tag(l.Language),
 		}
 	}
 
-	return p.catalog, nil
+	return languages, nil
 }
 
-func (p *concreteLanguageProvider) detect(text string) (string, error) {
-	httpClient := http.NewClient(p.authenticator)
+func (p *concreteLanguageProvider) detect(ctx context.Context, text string) (string, error) {
+	detections, err := p.detectDetailed(ctx, text)
+	if err != nil {
+		return "", err
+	}
 
-	resp, err := httpClient.SendRequest(
-		"GET",
-		fmt.Sprintf("%s?q=%s", p.router.detectURL(), url.QueryEscape(text)),
-		nil,
-		"text/plain",
-	)
+	return detections[0].Language, nil
+}
+
+func (p *concreteLanguageProvider) detectDetailed(ctx context.Context, text string) ([]translator.Detection, error) {
+	params := url.Values{}
+	params.Set("q", text)
 
+	resp, err := sendQuery(http.ContextWithTexts(ctx, text), p.httpClient, p.router.detectURL(), params)
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return nil, http.WrapError(err)
 	}
 
 	result, err := parseResponse(resp, &detectionPayload{})
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return nil, http.WrapError(err)
 	}
 
 	payload, ok := result.(*detectionPayload)
 	if !ok {
-		return "", tracerr.Error("Invalid response.")
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	if len(payload.Data.Detections) == 0 || len(payload.Data.Detections[0]) == 0 {
+		return nil, translator.ErrNoDetection
+	}
+
+	return rank(payload.Data.Detections[0]), nil
+}
+
+func (p *concreteLanguageProvider) detectBatch(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	detections := make([]translator.Detection, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		params := url.Values{}
+		for _, text := range batch {
+			params.Add("q", text)
+		}
+
+		resp, err := p.httpClient.SendRequest(
+			http.ContextWithTexts(ctx, batch...),
+			"POST",
+			p.router.detectURL(),
+			strings.NewReader(params.Encode()),
+			"application/x-www-form-urlencoded",
+		)
+
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		result, err := parseResponse(resp, &detectionPayload{})
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		payload, ok := result.(*detectionPayload)
+		if !ok || len(payload.Data.Detections) != len(batch) {
+			return nil, tracerr.Error("Invalid response.")
+		}
+
+		for _, candidates := range payload.Data.Detections {
+			detection := translator.Detection{}
+			if len(candidates) > 0 {
+				detection = rank(candidates)[0]
+			}
+			detections = append(detections, detection)
+		}
 	}
 
-	return payload.Data.Detections[0][0].Language, nil
+	return detections, nil
+}
+
+// rank converts the given candidates and sorts them by confidence, the most
+// likely candidate first.
+func rank(candidates []detectionCandidate) []translator.Detection {
+	detections := make([]translator.Detection, len(candidates))
+	for i, c := range candidates {
+		detections[i] = translator.Detection{
+			Language:   c.Language,
+			Confidence: c.Confidence,
+			Reliable:   c.IsReliable,
+		}
+	}
+
+	sort.SliceStable(detections, func(i, j int) bool {
+		return detections[i].Confidence > detections[j].Confidence
+	})
+
+	return detections
 }
@@ -1,11 +1,18 @@
 package google
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
 )
 
 func TestLanguages(t *testing.T) {
@@ -57,8 +64,8 @@ func TestLanguages(t *testing.T) {
 
 	router := &router{languagesEndpoint: server.URL}
 
-	provider := newLanguageProvider(authenticator, router)
-	languages, err := provider.languages()
+	provider := newLanguageProvider(_http.NewClient(authenticator), router, translator.DefaultCatalogTTL)
+	languages, err := provider.languages(context.Background(), "en")
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err.Error())
 	}
@@ -96,6 +103,89 @@ func TestLanguages(t *testing.T) {
 	}
 }
 
+func TestLanguagesIn(t *testing.T) {
+	names := map[string]string{
+		"de":    "Deutsch",
+		"en":    "German",
+		"zh-TW": "德文",
+	}
+
+	requestCounter := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := r.FormValue("target")
+		requestCounter[target]++
+
+		name, ok := names[target]
+		if !ok {
+			t.Fatalf("Unexpected `target` param in request: %s", target)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "data": { "languages": [ { "language": "de", "name": "%s" } ] } }`, name)
+	}))
+	defer server.Close()
+
+	router := &router{languagesEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	for _, locale := range []string{"de", "en", "zh-Hant", "de", "en", "zh-Hant"} {
+		languages, err := provider.languages(context.Background(), locale)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		expectedName := names[codeMap.Code(locale)]
+		if len(languages) != 1 || languages[0].Name != expectedName {
+			t.Fatalf("Unexpected languages for locale %s. Got: %v. Want name: %s.", locale, languages, expectedName)
+		}
+	}
+
+	for target, count := range requestCounter {
+		if count != 1 {
+			t.Fatalf("Expected 1 http request for target %s but counted %d.", target, count)
+		}
+	}
+}
+
+func TestLanguagesRefresh(t *testing.T) {
+	names := []string{"German", "Deutsch"}
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := names[requestCounter%len(names)]
+		requestCounter++
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "data": { "languages": [ { "language": "de", "name": "%s" } ] } }`, name)
+	}))
+	defer server.Close()
+
+	router := &router{languagesEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	for i, expectedName := range names {
+		if i > 0 {
+			if err := provider.refresh(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %s", err.Error())
+			}
+		}
+
+		languages, err := provider.languages(context.Background(), "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if languages[0].Name != expectedName {
+			t.Fatalf("Unexpected language name. Got: %s. Want: %s.", languages[0].Name, expectedName)
+		}
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+}
+
 func TestDetect(t *testing.T) {
 	expectedAPIKey := "my-secret-key"
 
@@ -146,9 +236,9 @@ func TestDetect(t *testing.T) {
 
 	router := &router{detectEndpoint: server.URL}
 
-	provider := newLanguageProvider(authenticator, router)
+	provider := newLanguageProvider(_http.NewClient(authenticator), router, translator.DefaultCatalogTTL)
 
-	languageCode, err := provider.detect(expectedText)
+	languageCode, err := provider.detect(context.Background(), expectedText)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err.Error())
@@ -162,3 +252,157 @@ func TestDetect(t *testing.T) {
 		)
 	}
 }
+
+func TestDetectLongText(t *testing.T) {
+	expectedText := strings.Repeat("Ich verstehe nur Bahnhof. ", 100)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		if r.PostFormValue("q") != expectedText {
+			t.Fatalf("Unexpected `q` param in request body. Got: %s. Want: %s", r.PostFormValue("q"), expectedText)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "data": { "detections": [ [ { "language": "de", "isReliable": true, "confidence": 0.98 } ] ] } }`)
+		return
+	}))
+	defer server.Close()
+
+	router := &router{detectEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewClient(newAuthenticator("my-secret-key")), router, translator.DefaultCatalogTTL)
+
+	languageCode, err := provider.detect(context.Background(), expectedText)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if languageCode != "de" {
+		t.Fatalf("Unexpected language code. Got: %s. Want: de.", languageCode)
+	}
+}
+
+func TestDetectDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "data": { "detections": [ [
+			{ "language": "nl", "isReliable": false, "confidence": 0.21 },
+			{ "language": "de", "isReliable": true, "confidence": 0.78 }
+		] ] } }`)
+	}))
+	defer server.Close()
+
+	router := &router{detectEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	expected := []translator.Detection{
+		{Language: "de", Confidence: 0.78, Reliable: true},
+		{Language: "nl", Confidence: 0.21, Reliable: false},
+	}
+
+	actual, err := provider.detectDetailed(context.Background(), "Hallo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Unexpected detections. Got: %v. Want: %v.", actual, expected)
+	}
+}
+
+func TestDetectNoDetection(t *testing.T) {
+	for _, payload := range []string{
+		`{ "data": { "detections": [] } }`,
+		`{ "data": { "detections": [ [] ] } }`,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, payload)
+		}))
+
+		router := &router{detectEndpoint: server.URL}
+		provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+		_, err := provider.detect(context.Background(), "?!")
+		server.Close()
+
+		if !errors.Is(err, translator.ErrNoDetection) {
+			t.Fatalf("Unexpected error for payload %s. Got: %v. Want: %v.", payload, err, translator.ErrNoDetection)
+		}
+	}
+}
+
+func TestDetectBatch(t *testing.T) {
+	texts := make([]string, maxBatchTexts+2)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("text-%d", i)
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %s", err.Error())
+		}
+
+		// answer with the index of each text as its language, leave every
+		// tenth text undetected
+		result := detectionPayload{}
+		for _, q := range r.PostForm["q"] {
+			var i int
+			fmt.Sscanf(q, "text-%d", &i)
+
+			candidates := []detectionCandidate{}
+			if i%10 != 0 {
+				candidates = append(candidates,
+					detectionCandidate{Language: "xx", Confidence: 0.1},
+					detectionCandidate{Language: fmt.Sprint(i), Confidence: 0.9, IsReliable: true},
+				)
+			}
+			result.Data.Detections = append(result.Data.Detections, candidates)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	router := &router{detectEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	detections, err := provider.detectBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+
+	if len(detections) != len(texts) {
+		t.Fatalf("Unexpected number of detections. Got: %d. Want: %d.", len(detections), len(texts))
+	}
+
+	for i, d := range detections {
+		expected := translator.Detection{Language: fmt.Sprint(i), Confidence: 0.9, Reliable: true}
+		if i%10 == 0 {
+			expected = translator.Detection{}
+		}
+
+		if d != expected {
+			t.Fatalf("Unexpected detection for text %d. Got: %v. Want: %v.", i, d, expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package google
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	baseURL       string
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	minConfidence float64
+	clientOptions []http.ClientOption
+}
+
+func newOptions(apiKey string, opts []Option) *options {
+	o := &options{
+		baseURL:       baseURL,
+		authenticator: newAuthenticator(apiKey),
+		retryPolicy:   http.DefaultRetryPolicy(),
+		catalogTTL:    translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.retryPolicy.Retryable == nil {
+		o.retryPolicy.Retryable = retryable
+	}
+
+	return o
+}
+
+func (o *options) httpClient() http.Client {
+	clientOptions := append([]http.ClientOption{http.WithRetryPolicy(o.retryPolicy)}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithBaseURL makes the Translator send requests to the given base URL
+// instead of https://www.googleapis.com/language/translate/v2/.
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		o.baseURL = url
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that adds the API
+// key to every request.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy. If the policy does not
+// specify a Retryable function, Google's rate limit errors are retried in
+// addition to the errors covered by http.Retryable.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
+
+// WithMinConfidence makes the Translator detect the source language of texts
+// that are translated without one by means of a separate detection request,
+// and fail with translator.ErrLowConfidence if the confidence of the
+// detection is below the given threshold between 0 and 1. By default, the
+// source language is detected as part of the translation request regardless
+// of its confidence. Batch translations are not affected.
+func WithMinConfidence(confidence float64) Option {
+	return func(o *options) {
+		o.minConfidence = confidence
+	}
+}
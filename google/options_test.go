@@ -0,0 +1,79 @@
+package google
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_http "github.com/st3v/translator/http"
+)
+
+func TestNewTranslatorOptions(t *testing.T) {
+	expectedUserAgent := "fake-user-agent"
+	expectedTranslation := "Hallo Welt!"
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.URL.Path != "/v2/" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.Header.Get("User-Agent") != expectedUserAgent {
+			t.Fatalf("Unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+
+		if r.FormValue("key") != "" {
+			t.Fatalf("Default authenticator should have been replaced. Got key: %s", r.FormValue("key"))
+		}
+
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "data": { "translations": [ { "translatedText": "%s" } ] } }`, expectedTranslation)
+	}))
+	defer server.Close()
+
+	translator := NewTranslator(
+		"my-secret-key",
+		WithBaseURL(server.URL+"/v2"),
+		WithHTTPClient(server.Client()),
+		WithTimeout(time.Second),
+		WithUserAgent(expectedUserAgent),
+		WithAuthenticator(authenticatorFunc(func(r *http.Request) error {
+			r.Header.Set("Authorization", "Bearer fake-token")
+			return nil
+		})),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+
+	actualTranslation, err := translator.Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Errorf("Unexpected translation. Got: %s. Want: %s.", actualTranslation, expectedTranslation)
+	}
+
+	if requestCounter != 2 {
+		t.Errorf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+}
+
+type authenticatorFunc func(r *http.Request) error
+
+func (f authenticatorFunc) Authenticate(r *http.Request) error {
+	return f(r)
+}
@@ -1,13 +1,20 @@
 package google
 
 import (
+	"bytes"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
 )
 
+const provider = "google"
+
 type errorPayload struct {
 	Error struct {
 		Errors []struct {
@@ -30,17 +37,20 @@ var parseResponse = func(resp *http.Response, target interface{}) (interface{},
 	errorPayload := &errorPayload{}
 	err = json.Unmarshal(body, errorPayload)
 	if err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			apiErr := _http.NewAPIError(provider, resp)
+			apiErr.Message = strings.TrimSpace(string(body))
+			return nil, apiErr
+		}
 		return nil, tracerr.Wrap(err)
 	}
 
 	if errorPayload.Error.Code != 0 {
-		return nil, tracerr.Errorf(
-			"API Error. Code: %d, Message: %s, Domain: %s, Reason: %s",
-			errorPayload.Error.Code,
-			errorPayload.Error.Message,
-			errorPayload.Error.Errors[0].Domain,
-			errorPayload.Error.Errors[0].Reason,
-		)
+		return nil, newAPIError(resp, errorPayload)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, _http.NewAPIError(provider, resp)
 	}
 
 	err = json.Unmarshal(body, target)
@@ -50,3 +60,59 @@ var parseResponse = func(resp *http.Response, target interface{}) (interface{},
 
 	return target, nil
 }
+
+// newAPIError classifies the error described by the given payload.
+// See https://cloud.google.com/translate/docs/reference/rest/v2/error-messages
+func newAPIError(resp *http.Response, payload *errorPayload) *translator.APIError {
+	err := _http.NewAPIError(provider, resp)
+	err.Code = strconv.Itoa(payload.Error.Code)
+	err.Message = payload.Error.Message
+
+	if len(payload.Error.Errors) > 0 {
+		err.Reason = payload.Error.Errors[0].Reason
+	}
+
+	switch err.Reason {
+	case "keyInvalid", "keyExpired", "authError", "unauthorized":
+		err.Err = translator.ErrUnauthorized
+	case "dailyLimitExceeded", "dailyLimitExceededUnreg", "quotaExceeded":
+		err.Err = translator.ErrQuotaExceeded
+		err.Retryable = false
+	case "rateLimitExceeded", "userRateLimitExceeded", "userRateLimitExceededUnreg":
+		err.Err = translator.ErrQuotaExceeded
+		err.Retryable = true
+	case "badRequest", "invalid", "invalidParameter":
+		if strings.Contains(strings.ToLower(err.Message), "language") {
+			err.Err = translator.ErrUnsupportedLanguage
+		}
+	}
+
+	return err
+}
+
+// retryable extends http.Retryable by Google's rate limit errors, which are
+// reported with status 403 rather than 429. The response body is restored
+// so that it can still be parsed after the check.
+func retryable(resp *http.Response, err error) bool {
+	if _http.Retryable(resp, err) {
+		return true
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	payload := &errorPayload{}
+	if err := json.Unmarshal(body, payload); err != nil || payload.Error.Code == 0 {
+		return false
+	}
+
+	return newAPIError(resp, payload).Retryable
+}
@@ -1,10 +1,14 @@
 package google
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/st3v/translator"
 )
 
 func TestResponseParserAPIError(t *testing.T) {
@@ -34,9 +38,132 @@ func TestResponseParserAPIError(t *testing.T) {
 		t.Errorf("Expected nil result but got: %#v", result)
 	}
 
-	expectedError := "API Error. Code: 666, Message: error-generic-message, Domain: error-domain, Reason: error-reason"
+	expectedError := "google API error. Status: 200, Code: 666, Reason: error-reason, Message: error-generic-message"
 
 	if !strings.HasPrefix(err.Error(), expectedError) {
 		t.Errorf("Unexpected Error. Got: '%s'. Want: '%s'.", err.Error(), expectedError)
 	}
+
+	var apiErr *translator.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError. Got: %#v", err)
+	}
+
+	if apiErr.Provider != "google" {
+		t.Errorf("Unexpected provider. Got: '%s'. Want: 'google'.", apiErr.Provider)
+	}
+}
+
+func TestResponseParserAPIErrorClassification(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		reason     string
+		message    string
+		retryable  bool
+		err        error
+	}{
+		{400, "keyInvalid", "Bad Request", false, translator.ErrUnauthorized},
+		{403, "dailyLimitExceeded", "Daily Limit Exceeded", false, translator.ErrQuotaExceeded},
+		{403, "userRateLimitExceeded", "User Rate Limit Exceeded", true, translator.ErrQuotaExceeded},
+		{400, "badRequest", "Bad language pair: en|xx", false, translator.ErrUnsupportedLanguage},
+		{400, "invalid", "Invalid Value", false, nil},
+		{503, "backendError", "Backend Error", true, nil},
+	} {
+		errorPayload := fmt.Sprintf(`{
+			"error": {
+				"errors": [ { "domain": "global", "reason": "%s", "message": "%s" } ],
+				"code": %d,
+				"message": "%s"
+			}
+		}`, tc.reason, tc.message, tc.statusCode, tc.message)
+
+		response := &http.Response{
+			StatusCode: tc.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(errorPayload)),
+		}
+
+		_, err := parseResponse(response, &struct{}{})
+
+		var apiErr *translator.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected APIError. Got: %#v", err)
+		}
+
+		if apiErr.Reason != tc.reason {
+			t.Errorf("Unexpected reason. Got: '%s'. Want: '%s'.", apiErr.Reason, tc.reason)
+		}
+
+		if apiErr.StatusCode != tc.statusCode {
+			t.Errorf("Unexpected status code. Got: %d. Want: %d.", apiErr.StatusCode, tc.statusCode)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Errorf("Unexpected retryable flag for reason %s. Got: %t. Want: %t.", tc.reason, apiErr.Retryable, tc.retryable)
+		}
+
+		if tc.err != nil && !errors.Is(err, tc.err) {
+			t.Errorf("Expected error for reason %s to be '%v'. Got: %v.", tc.reason, tc.err, apiErr.Err)
+		}
+
+		if tc.err == nil && apiErr.Err != nil {
+			t.Errorf("Unexpected sentinel error for reason %s: %v", tc.reason, apiErr.Err)
+		}
+	}
+}
+
+func TestResponseParserHTTPError(t *testing.T) {
+	response := &http.Response{
+		StatusCode: 502,
+		Status:     "502 Bad Gateway",
+		Body:       ioutil.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+	}
+
+	_, err := parseResponse(response, &struct{}{})
+
+	var apiErr *translator.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError. Got: %#v", err)
+	}
+
+	if !apiErr.Retryable {
+		t.Error("Expected APIError to be retryable.")
+	}
+
+	if apiErr.Message != "<html>Bad Gateway</html>" {
+		t.Errorf("Unexpected message: %s", apiErr.Message)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		reason     string
+		retryable  bool
+	}{
+		{403, "userRateLimitExceeded", true},
+		{403, "rateLimitExceeded", true},
+		{403, "dailyLimitExceeded", false},
+		{400, "keyInvalid", false},
+		{500, "backendError", true},
+	} {
+		errorPayload := fmt.Sprintf(
+			`{ "error": { "errors": [ { "reason": "%s" } ], "code": %d, "message": "fake-message" } }`,
+			tc.reason,
+			tc.statusCode,
+		)
+
+		response := &http.Response{
+			StatusCode: tc.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(errorPayload)),
+		}
+
+		if actual := retryable(response, nil); actual != tc.retryable {
+			t.Errorf("Unexpected result for reason %s. Got: %t. Want: %t.", tc.reason, actual, tc.retryable)
+		}
+
+		body, _ := ioutil.ReadAll(response.Body)
+		if string(body) != errorPayload {
+			t.Errorf("Response body has not been restored. Got: '%s'.", string(body))
+		}
+	}
 }
@@ -1,5 +1,7 @@
 package google
 
+import "strings"
+
 const baseURL = "https://www.googleapis.com/language/translate/v2/"
 
 type router struct {
@@ -9,8 +11,13 @@ type router struct {
 	detectEndpoint    string
 }
 
-func newRouter() *router {
+func newRouter(baseURL string) *router {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
 	return &router{
+		baseURL:           baseURL,
 		languagesEndpoint: baseURL + "languages",
 		detectEndpoint:    baseURL + "detect",
 		translateEndpoint: baseURL,
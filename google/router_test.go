@@ -0,0 +1,39 @@
+package google
+
+import "testing"
+
+func TestRouter(t *testing.T) {
+	for _, tc := range []struct {
+		baseURL           string
+		languagesEndpoint string
+		detectEndpoint    string
+		translateEndpoint string
+	}{
+		{
+			baseURL,
+			"https://www.googleapis.com/language/translate/v2/languages",
+			"https://www.googleapis.com/language/translate/v2/detect",
+			"https://www.googleapis.com/language/translate/v2/",
+		},
+		{
+			"http://localhost:8080/v2",
+			"http://localhost:8080/v2/languages",
+			"http://localhost:8080/v2/detect",
+			"http://localhost:8080/v2/",
+		},
+	} {
+		router := newRouter(tc.baseURL)
+
+		if router.languagesURL() != tc.languagesEndpoint {
+			t.Errorf("Unexpected languages URL. Got: %s. Want: %s.", router.languagesURL(), tc.languagesEndpoint)
+		}
+
+		if router.detectURL() != tc.detectEndpoint {
+			t.Errorf("Unexpected detect URL. Got: %s. Want: %s.", router.detectURL(), tc.detectEndpoint)
+		}
+
+		if router.translateURL() != tc.translateEndpoint {
+			t.Errorf("Unexpected translate URL. Got: %s. Want: %s.", router.translateURL(), tc.translateEndpoint)
+		}
+	}
+}
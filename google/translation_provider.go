@@ -1,61 +1,169 @@
 package google
 
 import (
-	"fmt"
+	"context"
+	nethttp "net/http"
 	"net/url"
+	"strings"
 
 	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
 	"github.com/st3v/translator/http"
 )
 
 type translationPayload struct {
 	Data struct {
 		Translations []struct {
-			TranslatedText string
+			TranslatedText         string
+			DetectedSourceLanguage string
+			Model                  string
 		}
 	}
 }
 
+// Google accepts at most 128 text segments per translation request and
+// recommends to keep requests below 5000 characters.
+const (
+	maxBatchTexts = 128
+	maxBatchChars = 5000
+)
+
+// Servers and proxies reject overly long URLs. Requests whose URL would be
+// longer than maxURLLength send their parameters in a form-encoded POST body.
+const maxURLLength = 2000
+
 type translationProvider interface {
-	translate(text, from, to string) (string, error)
+	translate(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error)
+	translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error)
 }
 
 type concreteTranslationProvider struct {
-	authenticator http.Authenticator
-	router        *router
+	httpClient http.Client
+	router     *router
 }
 
-func newTranslationProvider(a http.Authenticator, r *router) *concreteTranslationProvider {
+func newTranslationProvider(c http.Client, r *router) *concreteTranslationProvider {
 	return &concreteTranslationProvider{
-		authenticator: a,
-		router:        r,
+		httpClient: c,
+		router:     r,
 	}
 }
 
-func (t *concreteTranslationProvider) translate(text, from, to string) (string, error) {
-	httpClient := http.NewClient(t.authenticator)
+// translate requests the translation of the given text. The API supports
+// the Format and Model options, e.g. nmt or base. The result holds the
+// detected source language if from is empty, and the model if the API
+// reports it.
+func (t *concreteTranslationProvider) translate(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error) {
+	if err := opts.Validate(provider, "Format", "Model"); err != nil {
+		return translator.TranslateResult{}, err
+	}
 
-	uri := fmt.Sprintf(
-		"%s?q=%s&source=%s&target=%s",
-		t.router.translateURL(),
-		url.QueryEscape(text),
-		url.QueryEscape(from),
-		url.QueryEscape(to))
+	params := url.Values{}
+	params.Set("q", text)
+	setSource(params, from)
+	params.Set("target", to)
+	params.Set("format", formatParam(opts.Format))
 
-	resp, err := httpClient.SendRequest("GET", uri, nil, "text/plain")
+	if opts.Model != "" {
+		params.Set("model", opts.Model)
+	}
+
+	resp, err := sendQuery(http.ContextWithTexts(ctx, text), t.httpClient, t.router.translateURL(), params)
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return translator.TranslateResult{}, http.WrapError(err)
 	}
 
 	result, err := parseResponse(resp, &translationPayload{})
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return translator.TranslateResult{}, http.WrapError(err)
 	}
 
 	payload, ok := result.(*translationPayload)
-	if !ok {
-		return "", tracerr.Error("Invalid response.")
+	if !ok || len(payload.Data.Translations) == 0 {
+		return translator.TranslateResult{}, tracerr.Error("Invalid response.")
+	}
+
+	translation := payload.Data.Translations[0]
+
+	model := translation.Model
+	if model == "" {
+		model = opts.Model
+	}
+
+	return translator.TranslateResult{
+		Text:             translation.TranslatedText,
+		DetectedLanguage: translation.DetectedSourceLanguage,
+		Model:            model,
+	}, nil
+}
+
+func (t *concreteTranslationProvider) translateBatch(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	translations := make([]string, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		params := url.Values{}
+		setSource(params, from)
+		params.Set("target", to)
+		params.Set("format", formatParam(translator.Text))
+		for _, text := range batch {
+			params.Add("q", text)
+		}
+
+		resp, err := t.httpClient.SendRequest(
+			http.ContextWithTexts(ctx, batch...),
+			"POST",
+			t.router.translateURL(),
+			strings.NewReader(params.Encode()),
+			"application/x-www-form-urlencoded",
+		)
+
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		result, err := parseResponse(resp, &translationPayload{})
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		payload, ok := result.(*translationPayload)
+		if !ok || len(payload.Data.Translations) != len(batch) {
+			return nil, tracerr.Error("Invalid response.")
+		}
+
+		for _, translation := range payload.Data.Translations {
+			translations = append(translations, translation.TranslatedText)
+		}
+	}
+
+	return translations, nil
+}
+
+// setSource sets the source parameter unless from is empty, in which case
+// the API detects the source language.
+func setSource(params url.Values, from string) {
+	if from != "" {
+		params.Set("source", from)
+	}
+}
+
+// formatParam returns the value of the format parameter for the given
+// format. The API treats texts as HTML unless told otherwise.
+func formatParam(format translator.Format) string {
+	if format == translator.HTML {
+		return "html"
+	}
+	return "text"
+}
+
+// sendQuery sends the given parameters in the query string of a GET request,
+// or in the form-encoded body of a POST request if the URL would get too long.
+func sendQuery(ctx context.Context, c http.Client, uri string, params url.Values) (*nethttp.Response, error) {
+	query := params.Encode()
+
+	if len(uri)+len(query)+1 <= maxURLLength {
+		return c.SendRequest(ctx, "GET", uri+"?"+query, nil, "text/plain")
 	}
 
-	return payload.Data.Translations[0].TranslatedText, nil
+	return c.SendRequest(ctx, "POST", uri, strings.NewReader(query), "application/x-www-form-urlencoded")
 }
@@ -1,10 +1,17 @@
 package google
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
 )
 
 func TestTranslate(t *testing.T) {
@@ -41,6 +48,10 @@ func TestTranslate(t *testing.T) {
 			t.Fatalf("Unexpected `q` param in request. Got: %s. Want: %s", r.FormValue("q"), expectedOriginal)
 		}
 
+		if r.FormValue("format") != "text" {
+			t.Fatalf("Unexpected `format` param in request. Got: %s. Want: text", r.FormValue("format"))
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 
 		jsonResponse := fmt.Sprintf(
@@ -55,13 +66,15 @@ func TestTranslate(t *testing.T) {
 
 	authenticator := newAuthenticator(expectedAPIKey)
 	router := &router{translateEndpoint: server.URL}
-	provider := newTranslationProvider(authenticator, router)
+	provider := newTranslationProvider(_http.NewClient(authenticator), router)
 
-	actualTranslation, err := provider.translate(expectedOriginal, expectedSource, expectedTarget)
+	result, err := provider.translate(context.Background(), expectedOriginal, expectedSource, expectedTarget, translator.TranslateOptions{})
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err.Error())
 	}
 
+	actualTranslation := result.Text
+
 	if actualTranslation != expectedTranslation {
 		t.Errorf(
 			"Unexpected translation result. Got: '%s'. Want: '%s'.",
@@ -70,3 +83,274 @@ func TestTranslate(t *testing.T) {
 		)
 	}
 }
+
+func TestTranslateBatch(t *testing.T) {
+	expectedSource := "de"
+	expectedTarget := "en"
+
+	expectedAPIKey := "my-secret-key"
+
+	originals := make([]string, maxBatchTexts+2)
+	expectedTranslations := make([]string, len(originals))
+	for i := range originals {
+		originals[i] = fmt.Sprintf("Text %d", i)
+		expectedTranslations[i] = fmt.Sprintf("Translation %d", i)
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		if r.URL.Query().Get("key") != expectedAPIKey {
+			t.Fatalf("Unexpected `key` param in request. Got: %s. Want: %s", r.URL.Query().Get("key"), expectedAPIKey)
+		}
+
+		if r.FormValue("source") != expectedSource {
+			t.Fatalf("Unexpected `source` param in request. Got: %s. Want: %s", r.FormValue("source"), expectedSource)
+		}
+
+		if r.FormValue("target") != expectedTarget {
+			t.Fatalf("Unexpected `target` param in request. Got: %s. Want: %s", r.FormValue("target"), expectedTarget)
+		}
+
+		translations := make([]string, len(r.PostForm["q"]))
+		for i, q := range r.PostForm["q"] {
+			translations[i] = fmt.Sprintf(`{ "translatedText": "%s" }`, strings.Replace(q, "Text", "Translation", 1))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		fmt.Fprintf(w, `{ "data": { "translations": [ %s ] } }`, strings.Join(translations, ","))
+		return
+	}))
+	defer server.Close()
+
+	authenticator := newAuthenticator(expectedAPIKey)
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(authenticator), router)
+
+	actualTranslations, err := provider.translateBatch(context.Background(), originals, expectedSource, expectedTarget)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Errorf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+
+	if len(actualTranslations) != len(expectedTranslations) {
+		t.Fatalf(
+			"Unexpected number of translations. Got: %d. Want: %d.",
+			len(actualTranslations),
+			len(expectedTranslations),
+		)
+	}
+
+	for i := range expectedTranslations {
+		if actualTranslations[i] != expectedTranslations[i] {
+			t.Errorf(
+				"Unexpected translation result. Got: '%s'. Want: '%s'.",
+				actualTranslations[i],
+				expectedTranslations[i],
+			)
+		}
+	}
+}
+
+func TestTranslateAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+
+		fmt.Fprint(w, `{
+			"error": {
+				"errors": [ { "domain": "usageLimits", "reason": "dailyLimitExceeded", "message": "Daily Limit Exceeded" } ],
+				"code": 403,
+				"message": "Daily Limit Exceeded"
+			}
+		}`)
+		return
+	}))
+	defer server.Close()
+
+	authenticator := newAuthenticator("my-secret-key")
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(authenticator), router)
+
+	_, err := provider.translate(context.Background(), "foo", "en", "de", translator.TranslateOptions{})
+	if !errors.Is(err, translator.ErrQuotaExceeded) {
+		t.Fatalf("Expected ErrQuotaExceeded. Got: %v", err)
+	}
+}
+
+func TestTranslateLongText(t *testing.T) {
+	expectedOriginal := strings.Repeat("Rindfleischetikettierungsüberwachungsaufgabenübertragungsgesetz ", 100)
+	expectedTranslation := "WTF!?!"
+
+	expectedAPIKey := "my-secret-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		if r.URL.Query().Get("key") != expectedAPIKey {
+			t.Fatalf("Unexpected `key` param in request. Got: %s. Want: %s", r.URL.Query().Get("key"), expectedAPIKey)
+		}
+
+		if r.URL.Query().Get("q") != "" {
+			t.Fatal("Unexpected `q` param in request query.")
+		}
+
+		if r.PostFormValue("source") != "de" || r.PostFormValue("target") != "en" {
+			t.Fatalf("Unexpected `source` or `target` param in request body: %s, %s", r.PostFormValue("source"), r.PostFormValue("target"))
+		}
+
+		if r.PostFormValue("q") != expectedOriginal {
+			t.Fatalf("Unexpected `q` param in request body. Got: %s. Want: %s", r.PostFormValue("q"), expectedOriginal)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		fmt.Fprintf(w, `{ "data": { "translations": [ { "translatedText": "%s" } ] } }`, expectedTranslation)
+		return
+	}))
+	defer server.Close()
+
+	authenticator := newAuthenticator(expectedAPIKey)
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(authenticator), router)
+
+	result, err := provider.translate(context.Background(), expectedOriginal, "de", "en", translator.TranslateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	actualTranslation := result.Text
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslateHTML(t *testing.T) {
+	original := `<p class="intro">Tom &amp; Jerry <b>sind <i>Freunde</i></b>.<br/></p>`
+	expectedTranslation := `<p class="intro">Tom &amp; Jerry <b>are <i>friends</i></b>.<br/></p>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("format") != "html" {
+			t.Fatalf("Unexpected `format` param in request. Got: %s. Want: html", r.FormValue("format"))
+		}
+
+		if r.FormValue("q") != original {
+			t.Fatalf("Unexpected `q` param in request. Got: %s. Want: %s", r.FormValue("q"), original)
+		}
+
+		payload := &translationPayload{}
+		payload.Data.Translations = append(payload.Data.Translations, struct {
+			TranslatedText         string
+			DetectedSourceLanguage string
+			Model                  string
+		}{
+			TranslatedText: strings.NewReplacer("sind", "are", "Freunde", "friends").Replace(r.FormValue("q")),
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+		return
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator("my-secret-key")), router)
+
+	result, err := provider.translate(context.Background(), original, "de", "en", translator.TranslateOptions{Format: translator.HTML})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	actualTranslation := result.Text
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslateOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("model") != "nmt" {
+			t.Fatalf("Unexpected `model` param in request. Got: %s. Want: nmt", r.FormValue("model"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "data": { "translations": [ { "translatedText": "Hello" } ] } }`)
+		return
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator("my-secret-key")), router)
+
+	result, err := provider.translate(context.Background(), "Hallo", "de", "en", translator.TranslateOptions{Model: "nmt"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: 'Hello'.", result.Text)
+	}
+
+	if result.Model != "nmt" {
+		t.Fatalf("Unexpected model. Got: %s. Want: nmt.", result.Model)
+	}
+
+	for _, opts := range []translator.TranslateOptions{
+		{Category: "tech"},
+		{Profanity: translator.ProfanityMark},
+		{Glossary: "my-glossary"},
+	} {
+		_, err := provider.translate(context.Background(), "Hallo", "de", "en", opts)
+		if !errors.Is(err, translator.ErrUnsupportedOption) {
+			t.Fatalf("Expected ErrUnsupportedOption for %+v. Got: %v", opts, err)
+		}
+	}
+}
+
+func TestTranslateDetectedLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["source"]; ok {
+			t.Fatalf("Unexpected `source` param in request: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "data": { "translations": [ { "translatedText": "Hello", "detectedSourceLanguage": "de", "model": "nmt" } ] } }`)
+		return
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator("my-secret-key")), router)
+
+	result, err := provider.translate(context.Background(), "Hallo", "", "en", translator.TranslateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expected := translator.TranslateResult{Text: "Hello", DetectedLanguage: "de", Model: "nmt"}
+	if result != expected {
+		t.Fatalf("Unexpected translation result. Got: %+v. Want: %+v.", result, expected)
+	}
+}
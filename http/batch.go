@@ -0,0 +1,37 @@
+package http
+
+import "unicode/utf8"
+
+// Batch splits the given texts into consecutive batches that can each be sent
+// to an API endpoint with a single request. A batch holds at most maxTexts
+// texts with a combined length of at most maxChars characters. A text that
+// exceeds maxChars on its own is put into a batch by itself. Non-positive
+// limits are ignored. The order of the texts is preserved.
+func Batch(texts []string, maxTexts, maxChars int) [][]string {
+	var (
+		batches [][]string
+		current []string
+		chars   int
+	)
+
+	for _, text := range texts {
+		n := utf8.RuneCountInString(text)
+
+		full := maxTexts > 0 && len(current) >= maxTexts
+		full = full || (maxChars > 0 && len(current) > 0 && chars+n > maxChars)
+
+		if full {
+			batches = append(batches, current)
+			current, chars = nil, 0
+		}
+
+		current = append(current, text)
+		chars += n
+	}
+
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+
+	return batches
+}
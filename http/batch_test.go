@@ -0,0 +1,34 @@
+package http
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	for _, tc := range []struct {
+		texts    []string
+		maxTexts int
+		maxChars int
+		expected [][]string
+	}{
+		{nil, 2, 10, nil},
+		{[]string{"a", "b", "c"}, 2, 10, [][]string{{"a", "b"}, {"c"}}},
+		{[]string{"aaaa", "bbbb", "cc", "d"}, 10, 6, [][]string{{"aaaa"}, {"bbbb", "cc"}, {"d"}}},
+		{[]string{"a", "bbbbbbbb", "c"}, 10, 4, [][]string{{"a"}, {"bbbbbbbb"}, {"c"}}},
+		{[]string{"äöü", "ß"}, 10, 4, [][]string{{"äöü", "ß"}}},
+		{[]string{"a", "b", "c"}, 0, 0, [][]string{{"a", "b", "c"}}},
+	} {
+		actual := Batch(tc.texts, tc.maxTexts, tc.maxChars)
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf(
+				"Unexpected batches for %q (maxTexts: %d, maxChars: %d). Got: %q. Want: %q.",
+				tc.texts,
+				tc.maxTexts,
+				tc.maxChars,
+				actual,
+				tc.expected,
+			)
+		}
+	}
+}
@@ -1,47 +1,215 @@
 package http
 
 import (
+	"bytes"
+	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/st3v/tracerr"
 )
 
 // Client sends authenticated HTTP requests to API endpoints
 type Client interface {
-	SendRequest(method, uri string, body io.Reader, contentType string) (*http.Response, error)
+	SendRequest(ctx context.Context, method, uri string, body io.Reader, contentType string) (*http.Response, error)
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*client)
+
+// WithHTTPClient configures the client to send requests by means of the
+// given net/http client, e.g. to use a proxy or custom transport.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *client) {
+		c.client = httpClient
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take,
+// including reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests sent by the client.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader sets the given header on all requests sent by the client,
+// e.g. to specify the operation of APIs that expect it in a header. The
+// header is set before requests are authenticated.
+func WithHeader(key, value string) ClientOption {
+	return func(c *client) {
+		c.header.Set(key, value)
+	}
+}
+
+// WithRetryPolicy configures the client to repeat failed requests according
+// to the given policy. By default, requests are sent exactly once.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter makes the client wait for the given RateLimiter before
+// every attempt to send a request, including retries. Each attempt counts
+// as a single request and is charged with the characters of the texts that
+// have been attached to its context by means of ContextWithTexts. Share one
+// RateLimiter between all clients that are subject to the same quota.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *client) {
+		c.rateLimiter = limiter
+	}
+}
+
+type headerKey struct{}
+
+type charactersKey struct{}
+
+// ContextWithHeader returns a copy of the given context that carries the
+// passed header. Clients set the header on all requests sent with the
+// returned context, e.g. to specify a per-request display locale.
+func ContextWithHeader(ctx context.Context, key, value string) context.Context {
+	header := http.Header{}
+	if parent, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		header = parent.Clone()
+	}
+	header.Set(key, value)
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// ContextWithTexts returns a copy of the given context that carries the
+// number of characters of the passed texts. Clients charge every request
+// sent with the returned context with these characters, see
+// WithRateLimiter.
+func ContextWithTexts(ctx context.Context, texts ...string) context.Context {
+	return context.WithValue(ctx, charactersKey{}, characterCount(texts...))
 }
 
 type client struct {
 	client        *http.Client
 	authenticator Authenticator
+	retryPolicy   RetryPolicy
+	rateLimiter   *RateLimiter
+	timeout       time.Duration
+	userAgent     string
+	header        http.Header
 }
 
 // NewClient instantiates a Client and initializes it with the passed Authenticator.
-func NewClient(authenticator Authenticator) Client {
-	return &client{
+func NewClient(authenticator Authenticator, options ...ClientOption) Client {
+	c := &client{
 		client:        &http.Client{},
 		authenticator: authenticator,
+		header:        http.Header{},
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.timeout > 0 {
+		// don't modify a net/http client that has been passed in
+		httpClient := *c.client
+		httpClient.Timeout = c.timeout
+		c.client = &httpClient
+	}
+
+	return c
+}
+
+// SendRequest sends a request to the given URI. The request is aborted
+// as soon as the passed context is cancelled or its deadline expires.
+// Failed requests are repeated as specified by the client's retry policy.
+// Every attempt waits for the client's rate limiter, if any.
+func (h *client) SendRequest(ctx context.Context, method, uri string, body io.Reader, contentType string) (*http.Response, error) {
+	// buffer the body, it has to be sent again for every attempt
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = ioutil.ReadAll(body); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+	}
+
+	characters, _ := ctx.Value(charactersKey{}).(int)
+
+	for attempt := 1; ; attempt++ {
+		if h.rateLimiter != nil {
+			if err := h.rateLimiter.Wait(ctx, characters); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := h.send(ctx, method, uri, payload, contentType)
+
+		if attempt >= h.retryPolicy.MaxAttempts || !h.retryPolicy.retryable(response, err) {
+			if err != nil {
+				return nil, WrapError(err)
+			}
+			return response, nil
+		}
+
+		delay, ok := h.retryPolicy.backoff(attempt, response)
+		if !ok {
+			return response, nil
+		}
+
+		if response != nil {
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, WrapError(ctx.Err())
+		case <-timer.C:
+		}
 	}
 }
 
-func (h *client) SendRequest(method, uri string, body io.Reader, contentType string) (*http.Response, error) {
+func (h *client) send(ctx context.Context, method, uri string, payload []byte, contentType string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	request, err := http.NewRequest(method, uri, body)
 	if err != nil {
 		return nil, tracerr.Wrap(err)
 	}
 
+	request = request.WithContext(ctx)
 	request.Header.Add("Content-Type", contentType)
 
-	err = h.authenticator.Authenticate(request)
-	if err != nil {
-		return nil, tracerr.Wrap(err)
+	if h.userAgent != "" {
+		request.Header.Set("User-Agent", h.userAgent)
 	}
 
-	response, err := h.client.Do(request)
+	for key, values := range h.header {
+		request.Header[key] = values
+	}
+
+	if header, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		for key, values := range header {
+			request.Header[key] = values
+		}
+	}
+
+	err = h.authenticator.Authenticate(request)
 	if err != nil {
-		return nil, tracerr.Wrap(err)
+		return nil, err
 	}
 
-	return response, nil
+	return h.client.Do(request)
 }
@@ -1,13 +1,16 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestClientSendRequest(t *testing.T) {
@@ -71,6 +74,7 @@ func TestClientSendRequest(t *testing.T) {
 	defer server.Close()
 
 	response, err := client.SendRequest(
+		context.Background(),
 		expectedRequestMethod,
 		server.URL,
 		strings.NewReader(expectedRequestBody),
@@ -111,6 +115,7 @@ func TestClientSendRequestAuthenticateError(t *testing.T) {
 	client := NewClient(authenticator)
 
 	_, err := client.SendRequest(
+		context.Background(),
 		"POST",
 		"fake-url",
 		strings.NewReader("fake-body"),
@@ -125,3 +130,173 @@ func TestClientSendRequestAuthenticateError(t *testing.T) {
 		t.Fatalf("Expected fake-authentication-error. Got: %s", err.Error())
 	}
 }
+
+func TestClientSendRequestCancelled(t *testing.T) {
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	client := NewAuthenticatedClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.SendRequest(ctx, "GET", server.URL, nil, "text/plain")
+	if err == nil {
+		t.Fatal("Expected error but got none.")
+	}
+
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Fatalf("Expected context deadline to be exceeded. Got: %v", ctx.Err())
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	expectedUserAgent := "fake-user-agent"
+
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCounter, 1)
+
+		if r.Header.Get("User-Agent") != expectedUserAgent {
+			t.Errorf("Unexpected user agent. Want: '%s'. Got: '%s'", expectedUserAgent, r.Header.Get("User-Agent"))
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithHTTPClient(httpClient),
+		WithUserAgent(expectedUserAgent),
+		WithTimeout(10*time.Millisecond),
+	)
+
+	_, err := client.SendRequest(context.Background(), "GET", server.URL, nil, "text/plain")
+	if err == nil {
+		t.Fatal("Expected timeout error but got none.")
+	}
+
+	if n := atomic.LoadInt32(&requestCounter); n != 1 {
+		t.Fatalf("Expected 1 request but counted %d.", n)
+	}
+
+	if httpClient.Timeout != 0 {
+		t.Fatalf("The passed net/http client should not have been modified. Timeout: %s", httpClient.Timeout)
+	}
+}
+
+func TestClientWithHeader(t *testing.T) {
+	expectedTarget := "fake-service.FakeOperation"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Fake-Target") != expectedTarget {
+			t.Errorf("Unexpected header. Want: '%s'. Got: '%s'", expectedTarget, r.Header.Get("X-Fake-Target"))
+		}
+	}))
+	defer server.Close()
+
+	authenticator := newMockAuthenticator(func(request *http.Request) error {
+		if request.Header.Get("X-Fake-Target") != expectedTarget {
+			t.Errorf("Header should have been set before authentication. Got: '%s'", request.Header.Get("X-Fake-Target"))
+		}
+		return nil
+	})
+
+	client := NewClient(authenticator, WithHeader("X-Fake-Target", expectedTarget))
+
+	_, err := client.SendRequest(context.Background(), "POST", server.URL, strings.NewReader("{}"), "application/json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestClientContextWithHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Language") != "de" {
+			t.Errorf("Unexpected Accept-Language header. Want: 'de'. Got: '%s'", r.Header.Get("Accept-Language"))
+		}
+
+		if r.Header.Get("X-Fake-Target") != "fake-target" {
+			t.Errorf("Unexpected X-Fake-Target header. Want: 'fake-target'. Got: '%s'", r.Header.Get("X-Fake-Target"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(newMockAuthenticator(nil), WithHeader("Accept-Language", "en"))
+
+	ctx := ContextWithHeader(context.Background(), "X-Fake-Target", "fake-target")
+	ctx = ContextWithHeader(ctx, "Accept-Language", "de")
+
+	_, err := client.SendRequest(ctx, "GET", server.URL, nil, "text/plain")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCounter, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(0, 600)
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithRateLimiter(limiter),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}),
+	)
+
+	ctx := ContextWithTexts(context.Background(), "Hallo", "Welt")
+
+	response, err := client.SendRequest(ctx, "GET", server.URL, nil, "text/plain")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	response.Body.Close()
+
+	state := limiter.State()
+	if state.Requests != 2 {
+		t.Errorf("Unexpected number of admitted requests: %d", state.Requests)
+	}
+
+	if state.Characters != 18 {
+		t.Errorf("Unexpected number of admitted characters: %d", state.Characters)
+	}
+}
+
+func TestClientRateLimiterCancelled(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCounter, 1)
+	}))
+	defer server.Close()
+
+	client := NewClient(newMockAuthenticator(nil), WithRateLimiter(NewRateLimiter(0, 60)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.SendRequest(ContextWithTexts(ctx, string(make([]rune, 120))), "GET", server.URL, nil, "text/plain")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded. Got: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&requestCounter); n != 0 {
+		t.Fatalf("Expected no request but counted %d.", n)
+	}
+}
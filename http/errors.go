@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+)
+
+// NewAPIError returns a translator.APIError for the given provider and
+// HTTP response. The error is classified based on the response's status
+// code. Providers are expected to refine the classification based on the
+// details in the response body.
+func NewAPIError(provider string, response *http.Response) *translator.APIError {
+	err := &translator.APIError{
+		Provider:   provider,
+		StatusCode: response.StatusCode,
+		Message:    response.Status,
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized:
+		err.Err = translator.ErrUnauthorized
+	case response.StatusCode == http.StatusTooManyRequests:
+		err.Err = translator.ErrQuotaExceeded
+		err.Retryable = true
+	case response.StatusCode == http.StatusRequestTimeout:
+		err.Retryable = true
+	case response.StatusCode >= http.StatusInternalServerError &&
+		response.StatusCode != http.StatusNotImplemented:
+		err.Retryable = true
+	}
+
+	return err
+}
+
+// WrapError annotates the given error with a stack trace. Errors that are
+// meant to be inspected by callers, i.e. API errors and context errors, are
+// returned unchanged, since wrapping them with tracerr would hide them from
+// errors.Is and errors.As.
+func WrapError(err error) error {
+	var apiErr *translator.APIError
+	if errors.As(err, &apiErr) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	return tracerr.Wrap(err)
+}
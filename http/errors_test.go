@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestNewAPIError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		retryable  bool
+		err        error
+	}{
+		{http.StatusBadRequest, false, nil},
+		{http.StatusUnauthorized, false, translator.ErrUnauthorized},
+		{http.StatusRequestTimeout, true, nil},
+		{http.StatusTooManyRequests, true, translator.ErrQuotaExceeded},
+		{http.StatusInternalServerError, true, nil},
+		{http.StatusNotImplemented, false, nil},
+		{http.StatusServiceUnavailable, true, nil},
+	} {
+		response := &http.Response{
+			StatusCode: tc.statusCode,
+			Status:     http.StatusText(tc.statusCode),
+		}
+
+		err := NewAPIError("fake-provider", response)
+
+		if err.Provider != "fake-provider" {
+			t.Errorf("Unexpected provider for status %d: %s", tc.statusCode, err.Provider)
+		}
+
+		if err.StatusCode != tc.statusCode {
+			t.Errorf("Unexpected status code. Got: %d. Want: %d.", err.StatusCode, tc.statusCode)
+		}
+
+		if err.Retryable != tc.retryable {
+			t.Errorf("Unexpected retryable flag for status %d. Got: %t. Want: %t.", tc.statusCode, err.Retryable, tc.retryable)
+		}
+
+		if err.Err != tc.err {
+			t.Errorf("Unexpected sentinel error for status %d. Got: %v. Want: %v.", tc.statusCode, err.Err, tc.err)
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	apiErr := &translator.APIError{Err: translator.ErrQuotaExceeded}
+
+	for _, err := range []error{
+		apiErr,
+		fmt.Errorf("wrapped: %w", apiErr),
+		context.Canceled,
+		fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+	} {
+		if wrapped := WrapError(err); wrapped != err {
+			t.Errorf("Expected error to be returned unchanged. Got: %v. Want: %v.", wrapped, err)
+		}
+	}
+
+	err := errors.New("fake-error")
+	if wrapped := WrapError(err); wrapped == nil {
+		t.Error("Expected wrapped error but got nil.")
+	}
+}
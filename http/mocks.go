@@ -3,13 +3,13 @@ package http
 import "net/http"
 
 // NewAuthenticatedClient returns an HTTP client with a mocked-out authenticator.
-func NewAuthenticatedClient() Client {
+func NewAuthenticatedClient(options ...ClientOption) Client {
 	authenticator := newMockAuthenticator(func(request *http.Request) error {
 		request.Header.Set("Authorization", "fake-authorization")
 		return nil
 	})
 
-	return NewClient(authenticator)
+	return NewClient(authenticator, options...)
 }
 
 func newMockAuthenticator(authenticate func(request *http.Request) error) *mockAuthenticator {
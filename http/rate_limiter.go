@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// RateLimiterState is a snapshot of a RateLimiter intended for metrics.
+type RateLimiterState struct {
+	// AvailableRequests is the number of requests that can currently be
+	// made without blocking. Negative values indicate pending reservations.
+	AvailableRequests float64
+
+	// AvailableCharacters is the number of characters that can currently be
+	// sent without blocking. Negative values indicate pending reservations.
+	AvailableCharacters float64
+
+	// Waiting is the number of callers that are currently blocked.
+	Waiting int
+
+	// Requests is the total number of requests admitted so far.
+	Requests int64
+
+	// Characters is the total number of characters admitted so far.
+	Characters int64
+
+	// Waited is the total time callers have been blocked so far.
+	Waited time.Duration
+}
+
+// RateLimiter limits the number of requests per second and the number of
+// characters per minute sent to a translation API. It is safe for
+// concurrent use and is meant to be shared by all goroutines talking to
+// the same API.
+type RateLimiter struct {
+	mu         sync.Mutex
+	requests   *tokenBucket
+	characters *tokenBucket
+	state      RateLimiterState
+}
+
+// NewRateLimiter returns a RateLimiter that admits requestsPerSecond requests
+// per second and charactersPerMinute characters per minute. Limits that are
+// zero or negative are not enforced. Up to a second's worth of requests and
+// a minute's worth of characters may be sent in a single burst.
+func NewRateLimiter(requestsPerSecond float64, charactersPerMinute int) *RateLimiter {
+	return &RateLimiter{
+		requests:   newTokenBucket(requestsPerSecond, math.Ceil(requestsPerSecond)),
+		characters: newTokenBucket(float64(charactersPerMinute)/60, float64(charactersPerMinute)),
+	}
+}
+
+// Wait blocks until a single request carrying the given number of characters
+// may be sent, or until the context is done. In the latter case, the
+// context's error is returned and no capacity is consumed.
+func (l *RateLimiter) Wait(ctx context.Context, characters int) error {
+	l.mu.Lock()
+	now := time.Now()
+	delay := l.requests.reserve(now, 1)
+	if d := l.characters.reserve(now, float64(characters)); d > delay {
+		delay = d
+	}
+
+	if delay <= 0 {
+		l.admit(characters, 0)
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.state.Waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		l.state.Waiting--
+		l.admit(characters, delay)
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.state.Waiting--
+		l.requests.release(1)
+		l.characters.release(float64(characters))
+		l.mu.Unlock()
+		return WrapError(ctx.Err())
+	}
+}
+
+// State returns a snapshot of the limiter's current state.
+func (l *RateLimiter) State() RateLimiterState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	state := l.state
+	state.AvailableRequests = l.requests.available(now)
+	state.AvailableCharacters = l.characters.available(now)
+	return state
+}
+
+func (l *RateLimiter) admit(characters int, waited time.Duration) {
+	l.state.Requests++
+	l.state.Characters += int64(characters)
+	l.state.Waited += waited
+}
+
+func characterCount(texts ...string) int {
+	n := 0
+	for _, text := range texts {
+		n += utf8.RuneCountInString(text)
+	}
+	return n
+}
+
+// tokenBucket is not safe for concurrent use. A rate of zero or less
+// disables the bucket. Reservations may push the bucket into debt, which
+// allows requests larger than the bucket's capacity to eventually pass.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate, capacity float64) *tokenBucket {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &tokenBucket{
+		rate:     rate,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+func (b *tokenBucket) available(now time.Time) float64 {
+	if b.rate <= 0 {
+		return math.Inf(1)
+	}
+
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	return b.tokens
+}
+
+// reserve takes n tokens from the bucket and returns how long the caller
+// has to wait until the reservation is covered.
+func (b *tokenBucket) reserve(now time.Time, n float64) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+
+	b.tokens = b.available(now) - n
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// release returns n previously reserved tokens to the bucket.
+func (b *tokenBucket) release(n float64) {
+	if b.rate <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(b.capacity, b.tokens+n)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRequests(t *testing.T) {
+	limiter := NewRateLimiter(10, 0)
+
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		if err := limiter.Wait(context.Background(), 0); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("Expected 11th request to be delayed by ~100ms. Elapsed: %s", elapsed)
+	}
+
+	state := limiter.State()
+	if state.Requests != 11 {
+		t.Errorf("Unexpected number of admitted requests: %d", state.Requests)
+	}
+
+	if state.Waited <= 0 {
+		t.Errorf("Expected waited time to be recorded. Got: %s", state.Waited)
+	}
+
+	if !math.IsInf(state.AvailableCharacters, 1) {
+		t.Errorf("Expected characters to be unlimited. Got: %f", state.AvailableCharacters)
+	}
+}
+
+func TestRateLimiterCharacters(t *testing.T) {
+	limiter := NewRateLimiter(0, 600)
+
+	if err := limiter.Wait(context.Background(), 600); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("Expected request to be delayed by ~100ms. Elapsed: %s", elapsed)
+	}
+
+	if state := limiter.State(); state.Characters != 601 {
+		t.Errorf("Unexpected number of admitted characters: %d", state.Characters)
+	}
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, 0)
+
+	if err := limiter.Wait(context.Background(), 0); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded. Got: %v", err)
+	}
+
+	state := limiter.State()
+	if state.Requests != 1 {
+		t.Errorf("Cancelled request should not have been admitted. Admitted: %d", state.Requests)
+	}
+
+	if state.Waiting != 0 {
+		t.Errorf("Unexpected number of waiting callers: %d", state.Waiting)
+	}
+
+	if state.AvailableRequests < 0 {
+		t.Errorf("Cancelled reservation should have been released. Available: %f", state.AvailableRequests)
+	}
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/st3v/translator"
+)
+
+// RetryPolicy determines whether and when a Client repeats a failed request.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the initial attempt. Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the first retry. The delay doubles
+	// with every subsequent retry.
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts. If a response asks
+	// the client to wait longer than MaxBackoff by means of its Retry-After
+	// header, the response is returned without retrying the request.
+	// Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Jitter randomizes every delay by up to the given fraction of the delay
+	// in either direction, e.g. 0.2 results in delays between 80% and 120%
+	// of the calculated backoff. Values outside of [0, 1] are clamped.
+	Jitter float64
+
+	// Retryable decides whether a request should be repeated based on its
+	// response or the error returned by the transport, exactly one of which
+	// is non-nil. Defaults to the package-level Retryable function.
+	Retryable func(response *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by the translators in
+// this project unless configured otherwise.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		Retryable:   Retryable,
+	}
+}
+
+// Retryable reports whether a request that resulted in the given response
+// or error might succeed when repeated. This is the case for timeouts,
+// connection resets, rate limits, server-side errors, and retryable API
+// errors returned by the client's Authenticator.
+func Retryable(response *http.Response, err error) bool {
+	if err != nil {
+		return retryableError(err)
+	}
+
+	return NewAPIError("", response).Retryable
+}
+
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *translator.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (p RetryPolicy) retryable(response *http.Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(response, err)
+	}
+	return Retryable(response, err)
+}
+
+// backoff returns the delay before the given retry, the first retry being 1.
+// The returned bool is false if the request should not be retried at all
+// because the response asks for a delay longer than MaxBackoff.
+func (p RetryPolicy) backoff(retry int, response *http.Response) (time.Duration, bool) {
+	if response != nil {
+		if delay, ok := retryAfter(response); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				return 0, false
+			}
+			return delay, true
+		}
+	}
+
+	delay := p.BaseBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	jitter := p.Jitter
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+
+	delay = time.Duration(float64(delay) * (1 + jitter*(2*rand.Float64()-1)))
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	return delay, true
+}
+
+// retryAfter parses the Retry-After header of the given response, which
+// holds either a number of seconds or an HTTP date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+)
+
+func TestClientSendRequestRetry(t *testing.T) {
+	expectedRequestBody := "fake-request-body"
+	expectedResponseBody := "fake-response-body"
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Errorf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		if string(body) != expectedRequestBody {
+			t.Errorf("Unexpected request body in attempt %d. Want: '%s'. Got: '%s'", requestCounter, expectedRequestBody, string(body))
+		}
+
+		if requestCounter < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, expectedResponseBody)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}),
+	)
+
+	response, err := client.SendRequest(
+		context.Background(),
+		"POST",
+		server.URL,
+		strings.NewReader(expectedRequestBody),
+		"text/plain",
+	)
+
+	if err != nil {
+		t.Fatalf("Unexpected error when sending request: %s", err.Error())
+	}
+
+	if requestCounter != 3 {
+		t.Fatalf("Expected 3 requests but counted %d.", requestCounter)
+	}
+
+	actualBody, err := ioutil.ReadAll(response.Body)
+	defer response.Body.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error reading response body: %s", err.Error())
+	}
+
+	if string(actualBody) != expectedResponseBody {
+		t.Fatalf("Unexpected response body. Want: '%s'. Got: '%s'", expectedResponseBody, string(actualBody))
+	}
+}
+
+func TestClientSendRequestRetryExhausted(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+
+	response, err := client.SendRequest(context.Background(), "GET", server.URL, nil, "text/plain")
+	if err != nil {
+		t.Fatalf("Unexpected error when sending request: %s", err.Error())
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Unexpected status code: %d", response.StatusCode)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 requests but counted %d.", requestCounter)
+	}
+}
+
+func TestClientSendRequestNoRetry(t *testing.T) {
+	for _, statusCode := range []int{http.StatusBadRequest, http.StatusForbidden} {
+		requestCounter := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestCounter++
+			w.WriteHeader(statusCode)
+		}))
+
+		client := NewClient(
+			newMockAuthenticator(nil),
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}),
+		)
+
+		response, err := client.SendRequest(context.Background(), "GET", server.URL, nil, "text/plain")
+		if err != nil {
+			t.Fatalf("Unexpected error when sending request: %s", err.Error())
+		}
+		response.Body.Close()
+		server.Close()
+
+		if requestCounter != 1 {
+			t.Fatalf("Expected 1 request for status %d but counted %d.", statusCode, requestCounter)
+		}
+	}
+}
+
+func TestClientSendRequestRetryAfter(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Second}),
+	)
+
+	response, err := client.SendRequest(context.Background(), "GET", server.URL, nil, "text/plain")
+	if err != nil {
+		t.Fatalf("Unexpected error when sending request: %s", err.Error())
+	}
+	defer response.Body.Close()
+
+	if requestCounter != 1 {
+		t.Fatalf("Retry-After exceeds MaxBackoff, expected 1 request but counted %d.", requestCounter)
+	}
+}
+
+func TestClientSendRequestRetryCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		newMockAuthenticator(nil),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Hour}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.SendRequest(ctx, "GET", server.URL, nil, "text/plain")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded. Got: %v", err)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	for retry, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		delay, ok := policy.backoff(retry, nil)
+		if !ok || delay != expected {
+			t.Errorf("Unexpected backoff for retry %d. Want: %s. Got: %s.", retry, expected, delay)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay, _ := policy.backoff(1, nil)
+		if delay < 50*time.Millisecond || delay > 150*time.Millisecond {
+			t.Fatalf("Backoff with jitter out of range: %s", delay)
+		}
+	}
+
+	response := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	if delay, ok := policy.backoff(1, response); !ok || delay != time.Second {
+		t.Errorf("Unexpected backoff for Retry-After header. Want: 1s. Got: %s.", delay)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		err       error
+		retryable bool
+	}{
+		{context.Canceled, false},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), false},
+		{&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{errors.New("fake-error"), false},
+		{&translator.APIError{Retryable: true}, true},
+		{&translator.APIError{Retryable: false}, false},
+	} {
+		if actual := Retryable(nil, tc.err); actual != tc.retryable {
+			t.Errorf("Unexpected result for error %v. Want: %t. Got: %t.", tc.err, tc.retryable, actual)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package translator
+
+import (
+	"strings"
+)
+
+// Deprecated language subtags and their replacements as registered with
+// IANA, e.g. iw for Hebrew.
+var deprecatedLanguages = map[string]string{
+	"in": "id",
+	"iw": "he",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+}
+
+// Chinese is translated based on its script rather than the region it is
+// used in. Legacy codes such as zh-CHS are still in use by some APIs.
+var chineseScripts = map[string]string{
+	"zh-chs": "zh-Hans",
+	"zh-cht": "zh-Hant",
+	"zh-CN":  "zh-Hans",
+	"zh-SG":  "zh-Hans",
+	"zh-HK":  "zh-Hant",
+	"zh-MO":  "zh-Hant",
+	"zh-TW":  "zh-Hant",
+}
+
+// CanonicalTag returns the canonical BCP 47 tag for the given language code,
+// e.g. pt-BR for pt_br, he for iw or zh-Hant for zh-TW. Subtags are brought
+// into their conventional case, i.e. lower case languages, title case scripts
+// and upper case regions. Deprecated language subtags are replaced and
+// Chinese is identified by script rather than by region.
+func CanonicalTag(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	subtags := strings.FieldsFunc(code, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtag = strings.ToLower(subtag)
+			if replacement, ok := deprecatedLanguages[subtag]; ok {
+				subtag = replacement
+			}
+		case len(subtag) == 4 && isAlpha(subtag):
+			subtag = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2 && isAlpha(subtag), len(subtag) == 3 && !isAlpha(subtag):
+			subtag = strings.ToUpper(subtag)
+		default:
+			subtag = strings.ToLower(subtag)
+		}
+		subtags[i] = subtag
+	}
+
+	tag := strings.Join(subtags, "-")
+	if script, ok := chineseScripts[tag]; ok {
+		return script
+	}
+
+	return tag
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// CodeMap maps canonical BCP 47 tags to the language codes of a translation
+// API where the two differ, e.g. zh-Hant to zh-CHT.
+type CodeMap map[string]string
+
+// Code returns the API's code for the given language code or tag. Codes
+// that are not part of the map are returned unchanged, as are empty codes.
+func (m CodeMap) Code(code string) string {
+	if mapped, ok := m[CanonicalTag(code)]; ok {
+		return mapped
+	}
+	return code
+}
+
+// Tag returns the canonical BCP 47 tag for the given code of the API. If
+// several tags map to the same code, the first one in lexical order is
+// returned.
+func (m CodeMap) Tag(code string) string {
+	result := ""
+	for tag, mapped := range m {
+		if strings.EqualFold(mapped, code) && (result == "" || tag < result) {
+			result = tag
+		}
+	}
+
+	if result == "" {
+		return CanonicalTag(code)
+	}
+	return result
+}
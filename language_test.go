@@ -0,0 +1,73 @@
+package translator
+
+import "testing"
+
+func TestCanonicalTag(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"", ""},
+		{"en", "en"},
+		{"EN", "en"},
+		{"en-us", "en-US"},
+		{"EN-GB", "en-GB"},
+		{"pt_br", "pt-BR"},
+		{"iw", "he"},
+		{"jw", "jv"},
+		{"iw-IL", "he-IL"},
+		{"zh-CHS", "zh-Hans"},
+		{"zh-CHT", "zh-Hant"},
+		{"zh-cn", "zh-Hans"},
+		{"zh-TW", "zh-Hant"},
+		{"zh-hant", "zh-Hant"},
+		{"sr-latn", "sr-Latn"},
+		{"es-419", "es-419"},
+		{"tlh", "tlh"},
+		{"mww", "mww"},
+	} {
+		if have := CanonicalTag(tc.code); have != tc.want {
+			t.Fatalf("Unexpected canonical tag for %q. Got: %s. Want: %s.", tc.code, have, tc.want)
+		}
+	}
+}
+
+func TestCodeMap(t *testing.T) {
+	codes := CodeMap{
+		"zh-Hans": "zh-CHS",
+		"zh-Hant": "zh-CHT",
+		"he":      "iw",
+	}
+
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"", ""},
+		{"en", "en"},
+		{"zh-Hant", "zh-CHT"},
+		{"zh-TW", "zh-CHT"},
+		{"zh-hans", "zh-CHS"},
+		{"he", "iw"},
+		{"iw", "iw"},
+	} {
+		if have := codes.Code(tc.code); have != tc.want {
+			t.Fatalf("Unexpected code for %q. Got: %s. Want: %s.", tc.code, have, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"en", "en"},
+		{"zh-CHT", "zh-Hant"},
+		{"zh-chs", "zh-Hans"},
+		{"iw", "he"},
+		{"pt-br", "pt-BR"},
+	} {
+		if have := codes.Tag(tc.code); have != tc.want {
+			t.Fatalf("Unexpected tag for %q. Got: %s. Want: %s.", tc.code, have, tc.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package libretranslate
+
+import (
+	"context"
+
+	"github.com/st3v/translator"
+)
+
+type api struct {
+	lp languageProvider
+	tp translationProvider
+}
+
+// NewTranslator instantiates a new Translator for the LibreTranslate
+// instance at the given base URL, e.g. http://localhost:5000/ for a
+// self-hosted instance or https://libretranslate.com/ in combination with
+// WithAPIKey. The source language is detected if Translate is called with
+// an empty from.
+// The returned Translator also implements translator.ContextTranslator,
+// translator.OptionsTranslator, and translator.LanguageRefresher.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslator(baseURL string, opts ...Option) translator.Translator {
+	options := newOptions(opts)
+	httpClient := options.httpClient()
+	router := newRouter(baseURL)
+
+	return &api{
+		lp: newLanguageProvider(httpClient, router, options.catalogTTL),
+		tp: newTranslationProvider(httpClient, router),
+	}
+}
+
+func (a *api) Languages() ([]translator.Language, error) {
+	return a.LanguagesContext(context.Background())
+}
+
+func (a *api) Detect(text string) (string, error) {
+	return a.DetectContext(context.Background(), text)
+}
+
+func (a *api) Translate(text, from, to string) (string, error) {
+	return a.TranslateContext(context.Background(), text, from, to)
+}
+
+func (a *api) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return a.lp.languages(ctx)
+}
+
+func (a *api) RefreshLanguages(ctx context.Context) error {
+	return a.lp.refresh(ctx)
+}
+
+func (a *api) DetectContext(ctx context.Context, text string) (string, error) {
+	return a.lp.detect(ctx, text)
+}
+
+func (a *api) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	return a.tp.translate(ctx, text, codeMap.Code(from), codeMap.Code(to))
+}
+
+func (a *api) TranslateWithOptions(ctx context.Context, req translator.TranslateRequest) (translator.TranslateResult, error) {
+	text, err := a.tp.translateWithOptions(ctx, req.Text, codeMap.Code(req.From), codeMap.Code(req.To), req.TranslateOptions)
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	return translator.TranslateResult{Text: text}, nil
+}
@@ -0,0 +1,31 @@
+package libretranslate
+
+import (
+	"net/http"
+
+	_http "github.com/st3v/translator/http"
+)
+
+type authenticator struct {
+	apiKey string
+}
+
+func newAuthenticator(apiKey string) _http.Authenticator {
+	return &authenticator{
+		apiKey: apiKey,
+	}
+}
+
+// Authenticate adds the API key to the query of the given request. Instances
+// that do not require API keys ignore it, the parameter is therefore omitted
+// if no key has been configured.
+func (a *authenticator) Authenticate(request *http.Request) error {
+	if a.apiKey == "" {
+		return nil
+	}
+
+	params := request.URL.Query()
+	params.Set("api_key", a.apiKey)
+	request.URL.RawQuery = params.Encode()
+	return nil
+}
@@ -0,0 +1,11 @@
+package libretranslate
+
+import "github.com/st3v/translator"
+
+// codeMap maps canonical BCP 47 tags to the language codes of LibreTranslate
+// where the two differ.
+var codeMap = translator.CodeMap{
+	"fil":     "tl",
+	"zh-Hans": "zh",
+	"zh-Hant": "zt",
+}
@@ -0,0 +1,112 @@
+package libretranslate
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+type languagesPayload []struct {
+	Code string
+	Name string
+}
+
+type detectionPayload []struct {
+	Language   string
+	Confidence float64
+}
+
+type languageProvider interface {
+	languages(ctx context.Context) ([]translator.Language, error)
+	refresh(ctx context.Context) error
+	detect(ctx context.Context, text string) (string, error)
+}
+
+type concreteLanguageProvider struct {
+	router     *router
+	httpClient http.Client
+	catalog    *translator.Catalog
+}
+
+func newLanguageProvider(c http.Client, r *router, ttl time.Duration) *concreteLanguageProvider {
+	p := &concreteLanguageProvider{
+		router:     r,
+		httpClient: c,
+	}
+	p.catalog = translator.NewCatalog(ttl, p.fetchLanguages)
+	return p
+}
+
+func (p *concreteLanguageProvider) languages(ctx context.Context) ([]translator.Language, error) {
+	return p.catalog.Languages(ctx, "en")
+}
+
+func (p *concreteLanguageProvider) refresh(ctx context.Context) error {
+	return p.catalog.Refresh(ctx)
+}
+
+func (p *concreteLanguageProvider) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	resp, err := p.httpClient.SendRequest(ctx, "GET", p.router.languagesURL(), nil, "text/plain")
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &languagesPayload{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	payload, ok := result.(*languagesPayload)
+	if !ok {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	languages := make([]translator.Language, len(*payload))
+	for i, l := range *payload {
+		languages[i] = translator.Language{
+			Code: l.Code,
+			Name: l.Name,
+			Tag:  codeMap.Tag(l.Code),
+		}
+	}
+
+	return languages, nil
+}
+
+func (p *concreteLanguageProvider) detect(ctx context.Context, text string) (string, error) {
+	params := url.Values{}
+	params.Set("q", text)
+
+	resp, err := p.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, text),
+		"POST",
+		p.router.detectURL(),
+		strings.NewReader(params.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &detectionPayload{})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	payload, ok := result.(*detectionPayload)
+	if !ok {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	if len(*payload) == 0 {
+		return "", translator.ErrNoDetection
+	}
+
+	return (*payload)[0].Language, nil
+}
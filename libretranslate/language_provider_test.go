@@ -0,0 +1,101 @@
+package libretranslate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func TestLanguages(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "GET" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"code": "en", "name": "English", "targets": ["de", "en"]},
+			{"code": "de", "name": "German", "targets": ["de", "en"]},
+			{"code": "zt", "name": "Chinese (traditional)", "targets": ["en"]}
+		]`)
+	}))
+	defer server.Close()
+
+	router := &router{languagesEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	expectedLanguages := []translator.Language{
+		{Code: "en", Name: "English", Tag: "en"},
+		{Code: "de", Name: "German", Tag: "de"},
+		{Code: "zt", Name: "Chinese (traditional)", Tag: "zh-Hant"},
+	}
+
+	for i := 0; i < 2; i++ {
+		actualLanguages, err := provider.languages(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(actualLanguages, expectedLanguages) {
+			t.Fatalf("Unexpected languages. Got: %v. Want: %v.", actualLanguages, expectedLanguages)
+		}
+	}
+
+	if requestCounter != 1 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 1)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.PostFormValue("q") != "Bonjour le monde!" {
+			t.Fatalf("Unexpected `q` param in request: %s", r.PostFormValue("q"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"confidence": 90.0, "language": "fr"}, {"confidence": 10.0, "language": "en"}]`)
+	}))
+	defer server.Close()
+
+	router := &router{detectEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	actualLanguage, err := provider.detect(context.Background(), "Bonjour le monde!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualLanguage != "fr" {
+		t.Fatalf("Unexpected language. Got: %s. Want: %s.", actualLanguage, "fr")
+	}
+}
+
+func TestDetectEmptyResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+
+	router := &router{detectEndpoint: server.URL}
+	provider := newLanguageProvider(_http.NewAuthenticatedClient(), router, translator.DefaultCatalogTTL)
+
+	if _, err := provider.detect(context.Background(), "?"); !errors.Is(err, translator.ErrNoDetection) {
+		t.Fatalf("Expected ErrNoDetection for empty detection response. Got: %v", err)
+	}
+}
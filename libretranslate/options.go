@@ -0,0 +1,106 @@
+package libretranslate
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	apiKey        string
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	clientOptions []http.ClientOption
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		retryPolicy: http.DefaultRetryPolicy(),
+		catalogTTL:  translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.authenticator == nil {
+		o.authenticator = newAuthenticator(o.apiKey)
+	}
+
+	return o
+}
+
+func (o *options) httpClient() http.Client {
+	clientOptions := append([]http.ClientOption{http.WithRetryPolicy(o.retryPolicy)}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithAPIKey makes the Translator pass the given API key to instances that
+// require one.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that adds the API
+// key, if any, to every request, e.g. to authenticate with a reverse proxy
+// in front of the instance. WithAPIKey has no effect in that case.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
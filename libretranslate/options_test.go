@@ -0,0 +1,65 @@
+package libretranslate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_http "github.com/st3v/translator/http"
+)
+
+func TestNewTranslatorOptions(t *testing.T) {
+	expectedUserAgent := "fake-user-agent"
+	expectedTranslation := "Hallo Welt!"
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.URL.Path != "/libretranslate/translate" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("api_key") != "my-secret-key" {
+			t.Fatalf("Unexpected `api_key` param in request: %s", r.URL.Query().Get("api_key"))
+		}
+
+		if r.Header.Get("User-Agent") != expectedUserAgent {
+			t.Fatalf("Unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"translatedText": "%s"}`, expectedTranslation)
+	}))
+	defer server.Close()
+
+	translator := NewTranslator(
+		server.URL+"/libretranslate",
+		WithAPIKey("my-secret-key"),
+		WithHTTPClient(server.Client()),
+		WithTimeout(time.Second),
+		WithUserAgent(expectedUserAgent),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+
+	actualTranslation, err := translator.Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation. Got: %s. Want: %s.", actualTranslation, expectedTranslation)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Unexpected number of requests. Got: %d. Want: %d.", requestCounter, 2)
+	}
+}
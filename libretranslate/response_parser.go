@@ -0,0 +1,64 @@
+package libretranslate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const provider = "libretranslate"
+
+type errorPayload struct {
+	Error string
+}
+
+var parseResponse = func(resp *http.Response, target interface{}) (interface{}, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(resp, body)
+	}
+
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	return target, nil
+}
+
+// newAPIError classifies the error described by the given response. The
+// LibreTranslate API only describes errors by means of a message.
+func newAPIError(resp *http.Response, body []byte) *translator.APIError {
+	err := _http.NewAPIError(provider, resp)
+
+	payload := &errorPayload{}
+	if json.Unmarshal(body, payload) == nil && payload.Error != "" {
+		err.Message = payload.Error
+	} else if len(body) > 0 {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		err.Err = translator.ErrUnauthorized
+	case http.StatusBadRequest:
+		switch {
+		case strings.Contains(err.Message, "API key"):
+			err.Err = translator.ErrUnauthorized
+		case strings.Contains(err.Message, "is not supported"):
+			err.Err = translator.ErrUnsupportedLanguage
+		}
+	}
+
+	return err
+}
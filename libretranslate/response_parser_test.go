@@ -0,0 +1,54 @@
+package libretranslate
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestParseResponseError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		body       string
+		message    string
+		retryable  bool
+		err        error
+	}{
+		{http.StatusForbidden, `{"error": "Invalid API key"}`, "Invalid API key", false, translator.ErrUnauthorized},
+		{http.StatusBadRequest, `{"error": "Visit https://portal.libretranslate.com to get an API key"}`, "Visit https://portal.libretranslate.com to get an API key", false, translator.ErrUnauthorized},
+		{http.StatusBadRequest, `{"error": "xx is not supported"}`, "xx is not supported", false, translator.ErrUnsupportedLanguage},
+		{http.StatusBadRequest, `{"error": "Invalid request: missing q parameter"}`, "Invalid request: missing q parameter", false, nil},
+		{http.StatusTooManyRequests, `{"error": "Slowdown: 30 per 1 minute"}`, "Slowdown: 30 per 1 minute", true, translator.ErrQuotaExceeded},
+		{http.StatusBadGateway, "Bad Gateway\n", "Bad Gateway", true, nil},
+	} {
+		resp := &http.Response{
+			StatusCode: tc.statusCode,
+			Status:     fmt.Sprintf("%d %s", tc.statusCode, http.StatusText(tc.statusCode)),
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+
+		_, err := parseResponse(resp, &translationPayload{})
+
+		apiErr := &translator.APIError{}
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Unexpected error type. Got: %T. Want: *translator.APIError.", err)
+		}
+
+		if apiErr.Message != tc.message {
+			t.Fatalf("Unexpected message. Got: %s. Want: %s.", apiErr.Message, tc.message)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Fatalf("Unexpected retryable flag for %q. Got: %t. Want: %t.", tc.message, apiErr.Retryable, tc.retryable)
+		}
+
+		if apiErr.Err != tc.err {
+			t.Fatalf("Unexpected sentinel error for %q. Got: %v. Want: %v.", tc.message, apiErr.Err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package libretranslate
+
+import "strings"
+
+type router struct {
+	languagesEndpoint string
+	translateEndpoint string
+	detectEndpoint    string
+}
+
+func newRouter(baseURL string) *router {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	return &router{
+		languagesEndpoint: baseURL + "languages",
+		translateEndpoint: baseURL + "translate",
+		detectEndpoint:    baseURL + "detect",
+	}
+}
+
+func (r *router) languagesURL() string {
+	return r.languagesEndpoint
+}
+
+func (r *router) translateURL() string {
+	return r.translateEndpoint
+}
+
+func (r *router) detectURL() string {
+	return r.detectEndpoint
+}
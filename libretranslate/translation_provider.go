@@ -0,0 +1,82 @@
+package libretranslate
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+// autoSource makes LibreTranslate detect the source language.
+const autoSource = "auto"
+
+type translationPayload struct {
+	TranslatedText string `json:"translatedText"`
+}
+
+type translationProvider interface {
+	translate(ctx context.Context, text, from, to string) (string, error)
+	translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error)
+}
+
+type concreteTranslationProvider struct {
+	httpClient http.Client
+	router     *router
+}
+
+func newTranslationProvider(c http.Client, r *router) *concreteTranslationProvider {
+	return &concreteTranslationProvider{
+		httpClient: c,
+		router:     r,
+	}
+}
+
+// translate requests the translation of the given text. The source language
+// is detected if from is empty.
+func (t *concreteTranslationProvider) translate(ctx context.Context, text, from, to string) (string, error) {
+	return t.translateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+}
+
+// translateWithOptions is like translate but supports the Format option.
+func (t *concreteTranslationProvider) translateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (string, error) {
+	if err := opts.Validate(provider, "Format"); err != nil {
+		return "", err
+	}
+
+	if from == "" {
+		from = autoSource
+	}
+
+	params := url.Values{}
+	params.Set("q", text)
+	params.Set("source", from)
+	params.Set("target", to)
+	params.Set("format", opts.Format.String())
+
+	resp, err := t.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, text),
+		"POST",
+		t.router.translateURL(),
+		strings.NewReader(params.Encode()),
+		"application/x-www-form-urlencoded",
+	)
+
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	result, err := parseResponse(resp, &translationPayload{})
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	payload, ok := result.(*translationPayload)
+	if !ok {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	return payload.TranslatedText, nil
+}
@@ -0,0 +1,135 @@
+package libretranslate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func TestTranslate(t *testing.T) {
+	expectedOriginal := "Rindfleischetikettierungsüberwachungsaufgabenübertragungsgesetz"
+	expectedTranslation := "WTF!?!"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		if r.URL.Query().Get("api_key") != "my-secret-key" {
+			t.Fatalf("Unexpected `api_key` param in request: %s", r.URL.Query().Get("api_key"))
+		}
+
+		for param, expected := range map[string]string{
+			"q":      expectedOriginal,
+			"source": "de",
+			"target": "en",
+			"format": "text",
+		} {
+			if r.PostFormValue(param) != expected {
+				t.Fatalf("Unexpected `%s` param in request. Got: %s. Want: %s", param, r.PostFormValue(param), expected)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"translatedText": "%s"}`, expectedTranslation)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator("my-secret-key")), router)
+
+	actualTranslation, err := provider.translate(context.Background(), expectedOriginal, "de", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslateWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("format") != "html" {
+			t.Fatalf("Unexpected `format` param in request. Got: %s. Want: html", r.PostFormValue("format"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"translatedText": "<b>Hello</b>"}`)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router)
+
+	opts := translator.TranslateOptions{Format: translator.HTML}
+
+	actualTranslation, err := provider.translateWithOptions(context.Background(), "<b>Hallo</b>", "de", "en", opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != "<b>Hello</b>" {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, "<b>Hello</b>")
+	}
+
+	_, err = provider.translateWithOptions(context.Background(), "Hallo", "de", "en", translator.TranslateOptions{Glossary: "my-glossary"})
+	if !errors.Is(err, translator.ErrUnsupportedOption) {
+		t.Fatalf("Expected ErrUnsupportedOption. Got: %v", err)
+	}
+}
+
+func TestTranslateWithoutSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("source") != autoSource {
+			t.Fatalf("Unexpected `source` param in request. Got: %s. Want: %s", r.PostFormValue("source"), autoSource)
+		}
+
+		if _, ok := r.URL.Query()["api_key"]; ok {
+			t.Fatalf("Unexpected `api_key` param in request: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"detectedLanguage": {"confidence": 90, "language": "de"}, "translatedText": "Hello"}`)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewClient(newAuthenticator("")), router)
+
+	actualTranslation, err := provider.translate(context.Background(), "Hallo", "", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != "Hello" {
+		t.Fatalf("Unexpected translation result. Got: '%s'. Want: '%s'.", actualTranslation, "Hello")
+	}
+}
+
+func TestTranslateUnsupportedLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": "xx is not supported"}`)
+	}))
+	defer server.Close()
+
+	router := &router{translateEndpoint: server.URL}
+	provider := newTranslationProvider(_http.NewAuthenticatedClient(), router)
+
+	_, err := provider.translate(context.Background(), "Hello", "en", "xx")
+	if !errors.Is(err, translator.ErrUnsupportedLanguage) {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, translator.ErrUnsupportedLanguage)
+	}
+}
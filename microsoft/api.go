@@ -1,13 +1,26 @@
 package microsoft
 
 import (
+	"context"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
 	"github.com/st3v/translator"
-	msauth "github.com/st3v/translator/microsoft/auth"
 )
 
+// detailedDetector is implemented by translation providers that score the
+// languages they detect.
+type detailedDetector interface {
+	DetectDetailed(ctx context.Context, text string) ([]translator.Detection, error)
+}
+
 type api struct {
 	languageCatalog     LanguageCatalog
 	translationProvider TranslationProvider
+	codeMap             translator.CodeMap
+	detector            detailedDetector
+	minConfidence       float64
 }
 
 // NewTranslator returns a struct that implements the Translator
@@ -16,23 +29,144 @@ type api struct {
 // The function takes the subscriptionKey for a registered
 // Text Translation Service. Details on how to get such a key:
 // http://docs.microsofttranslator.com/text-translate.html.
-func NewTranslator(subscriptionKey string) translator.Translator {
-	router := newRouter()
-	authenticator := msauth.NewAuthenticator(subscriptionKey, router.AuthURL())
+// The returned Translator also implements translator.ContextTranslator,
+// translator.BatchTranslator, translator.OptionsTranslator,
+// translator.LocalizedTranslator, translator.BatchDetector, and
+// translator.LanguageRefresher.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslator(subscriptionKey string, opts ...Option) translator.Translator {
+	options := newOptions(subscriptionKey, opts)
+	router := newRouter(options.baseURL, options.authURL)
+	httpClient := options.httpClient()
 	return &api{
-		languageCatalog:     newLanguageCatalog(newLanguageProvider(authenticator, router)),
-		translationProvider: newTranslationProvider(authenticator, router),
+		languageCatalog:     newLanguageCatalog(newLanguageProvider(httpClient, router), options.catalogTTL),
+		translationProvider: newTranslationProvider(httpClient, router),
+		codeMap:             codeMap,
+		minConfidence:       options.minConfidence,
 	}
 }
 
 func (a *api) Translate(text, from, to string) (string, error) {
-	return a.translationProvider.Translate(text, from, to)
+	return a.TranslateContext(context.Background(), text, from, to)
 }
 
 func (a *api) Languages() ([]translator.Language, error) {
-	return a.languageCatalog.Languages()
+	return a.LanguagesContext(context.Background())
 }
 
 func (a *api) Detect(text string) (string, error) {
-	return a.translationProvider.Detect(text)
+	return a.DetectContext(context.Background(), text)
+}
+
+func (a *api) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	result, err := a.TranslateWithOptions(ctx, translator.TranslateRequest{Text: text, From: from, To: to})
+	return result.Text, err
+}
+
+// TranslateWithOptions implements translator.OptionsTranslator. The source
+// language is detected if the request does not specify one. The model of
+// the result is the requested category, or general, which is the category
+// of Microsoft's standard model. Microsoft bills every character of the
+// source text. Translators without a detector that scores its detections,
+// i.e. those of version 2 of the API, reject a minimum confidence.
+func (a *api) TranslateWithOptions(ctx context.Context, req translator.TranslateRequest) (translator.TranslateResult, error) {
+	start := time.Now()
+
+	if a.minConfidence > 0 && a.detector == nil {
+		return translator.TranslateResult{}, &translator.OptionError{
+			Provider: provider,
+			Option:   "MinConfidence",
+			Value:    strconv.FormatFloat(a.minConfidence, 'g', -1, 64),
+		}
+	}
+
+	from := a.codeMap.Code(req.From)
+	if from == "" && a.minConfidence > 0 {
+		var err error
+		if from, err = a.detectSource(ctx, req.Text); err != nil {
+			return translator.TranslateResult{}, err
+		}
+	}
+
+	result, err := a.translationProvider.TranslateWithOptions(ctx, req.Text, from, a.codeMap.Code(req.To), req.TranslateOptions)
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	if req.From == "" && result.DetectedLanguage == "" {
+		result.DetectedLanguage = from
+	}
+
+	result.Provider = provider
+	result.Model = req.Category
+	if result.Model == "" {
+		result.Model = defaultCategory
+	}
+	result.BilledCharacters = utf8.RuneCountInString(req.Text)
+	result.Latency = time.Since(start)
+
+	return result, nil
+}
+
+// detectSource returns the language of the given text as detected by the
+// detector, or translator.ErrLowConfidence if the score of the detection is
+// below the configured minimum.
+func (a *api) detectSource(ctx context.Context, text string) (string, error) {
+	detections, err := a.detector.DetectDetailed(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	if detections[0].Confidence < a.minConfidence {
+		return "", translator.ErrLowConfidence
+	}
+
+	return detections[0].Language, nil
+}
+
+func (a *api) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	return a.languageCatalog.Languages(ctx, "en")
+}
+
+func (a *api) RefreshLanguages(ctx context.Context) error {
+	return a.languageCatalog.Refresh(ctx)
+}
+
+func (a *api) LanguagesIn(displayLocale string) ([]translator.Language, error) {
+	return a.LanguagesInContext(context.Background(), displayLocale)
+}
+
+func (a *api) LanguagesInContext(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return a.languageCatalog.Languages(ctx, displayLocale)
+}
+
+func (a *api) DetectContext(ctx context.Context, text string) (string, error) {
+	return a.translationProvider.Detect(ctx, text)
+}
+
+func (a *api) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return a.TranslateBatchContext(context.Background(), texts, from, to)
+}
+
+func (a *api) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return a.translationProvider.TranslateArray(ctx, texts, a.codeMap.Code(from), a.codeMap.Code(to))
+}
+
+func (a *api) DetectBatch(texts []string) ([]translator.Detection, error) {
+	return a.DetectBatchContext(context.Background(), texts)
+}
+
+func (a *api) DetectBatchContext(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	languages, err := a.translationProvider.DetectArray(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	detections := make([]translator.Detection, len(languages))
+	for i, language := range languages {
+		detections[i] = translator.Detection{Language: language}
+	}
+
+	return detections, nil
 }
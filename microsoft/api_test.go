@@ -1,6 +1,8 @@
 package microsoft
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/st3v/translator"
@@ -26,6 +28,81 @@ func TestAPITranslate(t *testing.T) {
 	}
 }
 
+func TestAPITranslateCodeMap(t *testing.T) {
+	original := "Hallo"
+	expectedTranslation := "你好"
+
+	api := &api{
+		translationProvider: newMockTranslationProvider(original, "de", "zh-CHT", expectedTranslation, t),
+		codeMap:             codeMap,
+	}
+
+	actualTranslation, err := api.Translate(original, "de", "zh-Hant")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation: %s", actualTranslation)
+	}
+}
+
+func TestAPITranslateHTML(t *testing.T) {
+	original := "<b>Hallo</b>"
+	expectedTranslation := "<b>你好</b>"
+
+	provider := newMockTranslationProvider(original, "de", "zh-CHT", expectedTranslation, t)
+
+	api := &api{
+		translationProvider: provider,
+		codeMap:             codeMap,
+	}
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             original,
+		From:             "de",
+		To:               "zh-Hant",
+		TranslateOptions: translator.TranslateOptions{Format: translator.HTML},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != expectedTranslation {
+		t.Fatalf("Unexpected translation: %s", result.Text)
+	}
+
+	if provider.opts.Format != translator.HTML {
+		t.Fatalf("Unexpected format: %s", provider.opts.Format)
+	}
+}
+
+func TestAPITranslateBatch(t *testing.T) {
+	originals := []string{"Hallo", "Welt"}
+	expectedTranslation := "Hello"
+	from := "de"
+	to := "en"
+
+	api := &api{
+		translationProvider: newMockTranslationProvider("", from, to, expectedTranslation, t),
+	}
+
+	actualTranslations, err := api.TranslateBatch(originals, from, to)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if len(actualTranslations) != len(originals) {
+		t.Fatalf("Unexpected number of translations: %q", actualTranslations)
+	}
+
+	for _, translation := range actualTranslations {
+		if translation != expectedTranslation {
+			t.Fatalf("Unexpected translation: %s", translation)
+		}
+	}
+}
+
 func TestAPILanguages(t *testing.T) {
 	expectedLanguages := []translator.Language{
 		translator.Language{
@@ -42,10 +119,14 @@ func TestAPILanguages(t *testing.T) {
 		},
 	}
 
+	languageProvider := newMockLanguageProvider()
+	for _, l := range expectedLanguages {
+		languageProvider.codes = append(languageProvider.codes, l.Code)
+		languageProvider.names = append(languageProvider.names, l.Name)
+	}
+
 	api := &api{
-		languageCatalog: &languageCatalog{
-			languages: expectedLanguages,
-		},
+		languageCatalog: newLanguageCatalog(languageProvider, translator.DefaultCatalogTTL),
 	}
 
 	actualLanguages, err := api.Languages()
@@ -87,3 +168,125 @@ func TestAPIDetect(t *testing.T) {
 			text, expectedLanguage, actualLanguage)
 	}
 }
+
+func TestAPIDetectBatch(t *testing.T) {
+	texts := []string{"Hallo", "Welt"}
+
+	api := &api{
+		translationProvider: newMockTranslationProvider("", "de", "", "", t),
+	}
+
+	actualDetections, err := api.DetectBatch(texts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if len(actualDetections) != len(texts) {
+		t.Fatalf("Unexpected number of detections: %v", actualDetections)
+	}
+
+	for _, detection := range actualDetections {
+		if detection != (translator.Detection{Language: "de"}) {
+			t.Fatalf("Unexpected detection: %v", detection)
+		}
+	}
+}
+
+func TestNewTranslatorV3(t *testing.T) {
+	if _, ok := NewTranslatorV3("my-subscription-key").(TranslatorV3); !ok {
+		t.Fatal("Translator returned by NewTranslatorV3 does not implement TranslatorV3.")
+	}
+}
+
+func TestAPITranslateResult(t *testing.T) {
+	api := &api{
+		translationProvider: newMockTranslationProvider("Hallo", "de", "en", "Hello", t),
+	}
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "Hallo", From: "de", To: "en"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" {
+		t.Fatalf("Unexpected translation. Got: %s. Want: Hello.", result.Text)
+	}
+
+	if result.Provider != "microsoft" {
+		t.Fatalf("Unexpected provider. Got: %s. Want: microsoft.", result.Provider)
+	}
+
+	if result.Model != "general" {
+		t.Fatalf("Unexpected model. Got: %s. Want: general.", result.Model)
+	}
+
+	if result.BilledCharacters != 5 {
+		t.Fatalf("Unexpected billed characters. Got: %d. Want: 5.", result.BilledCharacters)
+	}
+
+	result, err = api.TranslateWithOptions(context.Background(), translator.TranslateRequest{
+		Text:             "Hallo",
+		From:             "de",
+		To:               "en",
+		TranslateOptions: translator.TranslateOptions{Category: "tech"},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Model != "tech" {
+		t.Fatalf("Unexpected model. Got: %s. Want: tech.", result.Model)
+	}
+}
+
+type mockDetailedDetector struct {
+	detections []translator.Detection
+}
+
+func (d *mockDetailedDetector) DetectDetailed(ctx context.Context, text string) ([]translator.Detection, error) {
+	return d.detections, nil
+}
+
+func TestAPITranslateMinConfidence(t *testing.T) {
+	detector := &mockDetailedDetector{
+		detections: []translator.Detection{{Language: "de", Confidence: 0.4}},
+	}
+
+	api := &api{
+		translationProvider: newMockTranslationProvider("Hallo", "de", "en", "Hello", t),
+		detector:            detector,
+		minConfidence:       0.5,
+	}
+
+	_, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "Hallo", From: "", To: "en"})
+	if !errors.Is(err, translator.ErrLowConfidence) {
+		t.Fatalf("Expected ErrLowConfidence. Got: %v", err)
+	}
+
+	detector.detections[0].Confidence = 0.9
+
+	result, err := api.TranslateWithOptions(context.Background(), translator.TranslateRequest{Text: "Hallo", From: "", To: "en"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" || result.DetectedLanguage != "de" {
+		t.Fatalf("Unexpected result. Got: %+v. Want: Hello from de.", result)
+	}
+}
+
+func TestAPITranslateMinConfidenceV2(t *testing.T) {
+	api := NewTranslator("fake-key", WithMinConfidence(0.5)).(*api)
+	api.translationProvider = newMockTranslationProvider("Hallo", "de", "en", "Hello", t)
+
+	_, err := api.Translate("Hallo", "de", "en")
+	if !errors.Is(err, translator.ErrUnsupportedOption) {
+		t.Fatalf("Expected ErrUnsupportedOption. Got: %v", err)
+	}
+
+	var optionErr *translator.OptionError
+	if !errors.As(err, &optionErr) || optionErr.Option != "MinConfidence" || optionErr.Value != "0.5" {
+		t.Fatalf("Unexpected option error: %v", err)
+	}
+}
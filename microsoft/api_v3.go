@@ -0,0 +1,145 @@
+package microsoft
+
+import (
+	"context"
+
+	"github.com/st3v/translator"
+)
+
+// TranslatorV3 is implemented by the Translator returned by NewTranslatorV3.
+// It exposes features of version 3 of Microsoft's API that have no
+// equivalent in the translator.Translator interface.
+type TranslatorV3 interface {
+	translator.ContextTranslator
+	translator.BatchTranslator
+	translator.OptionsTranslator
+	translator.LocalizedTranslator
+	translator.DetailedDetector
+	translator.BatchDetector
+	translator.LanguageRefresher
+	translator.Transliterator
+
+	// TranslateMulti translates text into all of the given languages at once
+	// and returns the translations keyed by language code.
+	TranslateMulti(text, from string, to []string) (map[string]string, error)
+	TranslateMultiContext(ctx context.Context, text, from string, to []string) (map[string]string, error)
+
+	// LookupDictionary returns alternative translations of a word or short
+	// phrase.
+	LookupDictionary(text, from, to string) ([]DictionaryTranslation, error)
+	LookupDictionaryContext(ctx context.Context, text, from, to string) ([]DictionaryTranslation, error)
+}
+
+type apiV3 struct {
+	*api
+	provider *translationProviderV3
+	scripts  *scriptCatalogV3
+}
+
+// NewTranslatorV3 returns a Translator that is backed by version 3 of
+// Microsoft's Translator Text API. By default, the given subscription key is
+// passed directly to the API. Use WithRegion for regional Translator
+// resources and WithTokenAuthentication to exchange the key for access
+// tokens instead.
+// The returned Translator also implements translator.ContextTranslator,
+// translator.BatchTranslator, translator.OptionsTranslator,
+// translator.LocalizedTranslator, translator.DetailedDetector,
+// translator.BatchDetector, translator.LanguageRefresher,
+// translator.Transliterator and TranslatorV3.
+// Failed requests are retried according to http.DefaultRetryPolicy unless
+// configured otherwise by means of the given options.
+func NewTranslatorV3(subscriptionKey string, opts ...Option) translator.Translator {
+	options := newOptionsV3(subscriptionKey, opts)
+	router := newRouterV3(options.baseURL, options.authURL)
+	httpClient := options.httpClient()
+	provider := newTranslationProviderV3(httpClient, router)
+	return &apiV3{
+		api: &api{
+			languageCatalog:     newLanguageCatalogV3(httpClient, router, options.catalogTTL),
+			translationProvider: provider,
+			codeMap:             codeMapV3,
+			detector:            provider,
+			minConfidence:       options.minConfidence,
+		},
+		provider: provider,
+		scripts:  newScriptCatalogV3(httpClient, router, options.catalogTTL),
+	}
+}
+
+func (a *apiV3) DetectDetailed(text string) ([]translator.Detection, error) {
+	return a.DetectDetailedContext(context.Background(), text)
+}
+
+func (a *apiV3) DetectDetailedContext(ctx context.Context, text string) ([]translator.Detection, error) {
+	return a.provider.DetectDetailed(ctx, text)
+}
+
+func (a *apiV3) DetectBatch(texts []string) ([]translator.Detection, error) {
+	return a.DetectBatchContext(context.Background(), texts)
+}
+
+// DetectBatchContext overrides the implementation of api to report the
+// scores of the detections.
+func (a *apiV3) DetectBatchContext(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	return a.provider.DetectBatch(ctx, texts)
+}
+
+func (a *apiV3) TranslateMulti(text, from string, to []string) (map[string]string, error) {
+	return a.TranslateMultiContext(context.Background(), text, from, to)
+}
+
+// TranslateMultiContext maps the given language codes to the codes of the
+// API and keys the returned translations by the given target languages.
+func (a *apiV3) TranslateMultiContext(ctx context.Context, text, from string, to []string) (map[string]string, error) {
+	codes := make([]string, len(to))
+	for i, lang := range to {
+		codes[i] = a.codeMap.Code(lang)
+	}
+
+	translations, err := a.provider.TranslateMulti(ctx, text, a.codeMap.Code(from), codes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(to))
+	for i, lang := range to {
+		result[lang] = translations[codes[i]]
+	}
+
+	return result, nil
+}
+
+func (a *apiV3) Transliterate(text, language, fromScript, toScript string) (string, error) {
+	return a.TransliterateContext(context.Background(), text, language, fromScript, toScript)
+}
+
+func (a *apiV3) TransliterateContext(ctx context.Context, text, language, fromScript, toScript string) (string, error) {
+	return a.provider.Transliterate(ctx, text, a.codeMap.Code(language), fromScript, toScript)
+}
+
+func (a *apiV3) Scripts(language string) ([]translator.Script, error) {
+	return a.ScriptsContext(context.Background(), language)
+}
+
+func (a *apiV3) ScriptsContext(ctx context.Context, language string) ([]translator.Script, error) {
+	return a.scripts.Scripts(ctx, a.codeMap.Code(language))
+}
+
+// RefreshLanguages refreshes the cached languages as well as the cached
+// scripts of the languages that can be transliterated.
+func (a *apiV3) RefreshLanguages(ctx context.Context) error {
+	err := a.languageCatalog.Refresh(ctx)
+	if scriptsErr := a.scripts.Refresh(ctx); err == nil {
+		err = scriptsErr
+	}
+
+	return err
+}
+
+func (a *apiV3) LookupDictionary(text, from, to string) ([]DictionaryTranslation, error) {
+	return a.LookupDictionaryContext(context.Background(), text, from, to)
+}
+
+func (a *apiV3) LookupDictionaryContext(ctx context.Context, text, from, to string) ([]DictionaryTranslation, error) {
+	return a.provider.LookupDictionary(ctx, text, a.codeMap.Code(from), a.codeMap.Code(to))
+}
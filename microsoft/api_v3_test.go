@@ -0,0 +1,106 @@
+package microsoft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAPIV3TranslateMultiCodeMap(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if from := r.URL.Query().Get("from"); from != "pt-pt" {
+			t.Fatalf("Unexpected `from` param in request: %s", from)
+		}
+
+		if to := r.URL.Query()["to"]; !reflect.DeepEqual(to, []string{"zh-Hant", "fr-ca"}) {
+			t.Fatalf("Unexpected `to` params in request: %v", to)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"你好","to":"zh-Hant"},{"text":"Bonjour","to":"fr-ca"}]}]`)
+	})
+	defer closeServer()
+
+	api := &apiV3{
+		api:      &api{translationProvider: provider, codeMap: codeMapV3},
+		provider: provider,
+	}
+
+	actual, err := api.TranslateMulti("Olá", "pt-PT", []string{"zh-TW", "fr-CA"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := map[string]string{"zh-TW": "你好", "fr-CA": "Bonjour"}; !reflect.DeepEqual(actual, want) {
+		t.Fatalf("Unexpected translations. Want: %v. Got: %v.", want, actual)
+	}
+}
+
+func TestAPIV3LookupDictionaryCodeMap(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if from := r.URL.Query().Get("from"); from != "pt-pt" {
+			t.Fatalf("Unexpected `from` param in request: %s", from)
+		}
+
+		if to := r.URL.Query().Get("to"); to != "zh-Hant" {
+			t.Fatalf("Unexpected `to` param in request: %s", to)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[]}]`)
+	})
+	defer closeServer()
+
+	api := &apiV3{
+		api:      &api{translationProvider: provider, codeMap: codeMapV3},
+		provider: provider,
+	}
+
+	if _, err := api.LookupDictionary("olá", "pt-PT", "zh-TW"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestAPIV3RefreshLanguagesScripts(t *testing.T) {
+	var scriptRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("scope") != "transliteration" {
+			fmt.Fprint(w, `{"translation":{"ar":{"name":"Arabic","nativeName":"العربية","dir":"rtl"}}}`)
+			return
+		}
+
+		code := "Arab"
+		if atomic.AddInt32(&scriptRequests, 1) > 1 {
+			code = "Latn"
+		}
+
+		fmt.Fprintf(w, `{"transliteration":{"ar":{"scripts":[{"code":"%s"}]}}}`, code)
+	}))
+	defer server.Close()
+
+	api := NewTranslatorV3("fake-key", WithBaseURL(server.URL)).(*apiV3)
+
+	if _, err := api.Scripts("ar"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if err := api.RefreshLanguages(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	scripts, err := api.Scripts("ar")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if len(scripts) != 1 || scripts[0].Code != "Latn" {
+		t.Fatalf("Unexpected scripts after refresh: %v", scripts)
+	}
+}
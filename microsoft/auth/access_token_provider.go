@@ -1,16 +1,21 @@
 package auth
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"time"
 
 	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
 )
 
+const provider = "microsoft"
+
 // The AccessTokenProvider handles access tokens for Microsoft's API endpoints.
 type AccessTokenProvider interface {
-	RefreshToken(*accessToken) error
+	RefreshToken(context.Context, *accessToken) error
 }
 
 type accessTokenProvider struct {
@@ -25,23 +30,20 @@ func newAccessTokenProvider(subscribtionKey, authURL string) AccessTokenProvider
 	}
 }
 
-func (p *accessTokenProvider) RefreshToken(token *accessToken) error {
+func (p *accessTokenProvider) RefreshToken(ctx context.Context, token *accessToken) error {
 	req, err := http.NewRequest("POST", p.authURL, nil)
 	if err != nil {
 		return tracerr.Wrap(err)
 	}
 
+	req = req.WithContext(ctx)
 	req.Header.Add("Ocp-Apim-Subscription-Key", p.key)
 
 	client := new(http.Client)
 
 	response, err := client.Do(req)
 	if err != nil {
-		return tracerr.Wrap(err)
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return tracerr.Errorf("Unexpected Status: %s", response.Status)
+		return _http.WrapError(err)
 	}
 
 	defer response.Body.Close()
@@ -50,6 +52,17 @@ func (p *accessTokenProvider) RefreshToken(token *accessToken) error {
 		return tracerr.Wrap(err)
 	}
 
+	if response.StatusCode != http.StatusOK {
+		apiErr := _http.NewAPIError(provider, response)
+		if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+			apiErr.Err = translator.ErrUnauthorized
+		}
+		if len(body) > 0 {
+			apiErr.Message = string(body)
+		}
+		return apiErr
+	}
+
 	token.Token = string(body)
 	token.ExpiresAt = time.Now().Add(10 * time.Minute)
 
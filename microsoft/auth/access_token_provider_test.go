@@ -1,11 +1,15 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/st3v/translator"
 )
 
 // and is able to generate a valid access token from the server's response.
@@ -33,15 +37,65 @@ func TestAccessTokenProviderRefreshToken(t *testing.T) {
 	accessTokenProvider := newAccessTokenProvider(subscriptionKey, server.URL)
 
 	actualToken := new(accessToken)
-	if err := accessTokenProvider.RefreshToken(actualToken); err != nil {
+	if err := accessTokenProvider.RefreshToken(context.Background(), actualToken); err != nil {
 		t.Fatalf("Unexpected error returned by RefreshToken: %v", err.Error())
 	}
 
 	if have, want := actualToken.Token, expectedToken; have != want {
-		t.Fatalf("Unexpected Token: want %q, have %q.", actualToken.Token)
+		t.Fatalf("Unexpected Token: want %q, have %q.", want, have)
 	}
 
 	if s := actualToken.ExpiresAt.Sub(time.Now()).Seconds(); s < 598 || s > 600 {
 		t.Fatalf("Unexpected ExpiresAt %q for access token generated from http response.", actualToken.ExpiresAt)
 	}
 }
+
+// Make sure the token request is aborted once the context is cancelled.
+func TestAccessTokenProviderRefreshTokenCancelled(t *testing.T) {
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	accessTokenProvider := newAccessTokenProvider("private", server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	token := new(accessToken)
+	if err := accessTokenProvider.RefreshToken(ctx, token); err == nil {
+		t.Fatal("Expected error but got none.")
+	}
+
+	if token.Token != "" {
+		t.Fatalf("Token should not have been set. Got: %q.", token.Token)
+	}
+}
+
+// Make sure a rejected subscription key results in an unauthorized API error.
+func TestAccessTokenProviderRefreshTokenUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, "Access denied due to invalid subscription key.")
+	}))
+	defer server.Close()
+
+	accessTokenProvider := newAccessTokenProvider("invalid", server.URL)
+
+	err := accessTokenProvider.RefreshToken(context.Background(), new(accessToken))
+	if !errors.Is(err, translator.ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized. Got: %v", err)
+	}
+
+	var apiErr *translator.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError. Got: %#v", err)
+	}
+
+	if have, want := apiErr.Message, "Access denied due to invalid subscription key."; have != want {
+		t.Fatalf("Unexpected message: want %q, have %q.", want, have)
+	}
+}
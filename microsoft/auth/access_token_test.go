@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -37,12 +38,12 @@ type mockAccessTokenProvider struct {
 	refreshToken func(token *accessToken) error
 }
 
-func (p *mockAccessTokenProvider) RefreshToken(token *accessToken) error {
+func (p *mockAccessTokenProvider) RefreshToken(ctx context.Context, token *accessToken) error {
 	return p.refreshToken(token)
 }
 
 func (a *authenticator) expectedAuthToken(t *testing.T) string {
-	token, err := a.authToken()
+	token, err := a.authToken(context.Background())
 	if err != nil {
 		t.Fatalf("Unexpected error getting authToken from authenticator: %s", err.Error())
 	}
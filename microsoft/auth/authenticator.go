@@ -1,9 +1,9 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 
-	"github.com/st3v/tracerr"
 	_http "github.com/st3v/translator/http"
 )
 
@@ -26,31 +26,42 @@ func NewAuthenticator(subscriptionKey, authURL string) _http.Authenticator {
 }
 
 func (a *authenticator) Authenticate(request *http.Request) error {
-	authToken, err := a.authToken()
+	authToken, err := a.authToken(request.Context())
 	if err != nil {
-		return tracerr.Wrap(err)
+		return _http.WrapError(err)
 	}
 
 	request.Header.Add("Authorization", authToken)
 	return nil
 }
 
-func (a *authenticator) authToken() (string, error) {
-	// grab the token
-	accessToken := <-a.accessTokenChan
+func (a *authenticator) authToken(ctx context.Context) (string, error) {
+	// grab the token, unless the context is done before another caller
+	// finished refreshing it
+	var token *accessToken
+	select {
+	case token = <-a.accessTokenChan:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	if token == nil {
+		token = new(accessToken)
+	}
 
 	// make sure it's valid, otherwise request a new one
-	if accessToken == nil || accessToken.expired() {
-		err := a.accessTokenProvider.RefreshToken(accessToken)
-		if err != nil || accessToken == nil {
-			a.accessTokenChan <- nil
-			return "", tracerr.Wrap(err)
+	if token.expired() {
+		err := a.accessTokenProvider.RefreshToken(ctx, token)
+		if err != nil {
+			// put the stale token back, the next caller will try to refresh it again
+			a.accessTokenChan <- token
+			return "", _http.WrapError(err)
 		}
 	}
 
 	// put the token back on the channel
-	a.accessTokenChan <- accessToken
+	a.accessTokenChan <- token
 
 	// return authToken
-	return "Bearer " + accessToken.Token, nil
+	return "Bearer " + token.Token, nil
 }
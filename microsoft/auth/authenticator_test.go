@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -12,7 +13,7 @@ import (
 func TestAuthenticatorAuthToken(t *testing.T) {
 	authenticator := newMockAuthenticator(newMockAccessToken(100))
 
-	authToken, err := authenticator.authToken()
+	authToken, err := authenticator.authToken(context.Background())
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err.Error())
 	}
@@ -27,7 +28,7 @@ func TestAuthenticatorAuthToken(t *testing.T) {
 func TestAuthenticatorAuthenticate(t *testing.T) {
 	authenticator := newMockAuthenticator(newMockAccessToken(10 * time.Minute))
 
-	authToken, err := authenticator.authToken()
+	authToken, err := authenticator.authToken(context.Background())
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err.Error())
 	}
@@ -77,7 +78,7 @@ func TestAuthenticatorConcurrentAuthenticate(t *testing.T) {
 		errChan := make(chan error)
 		go func() {
 			<-readyGo
-			authToken, err := authenticator.authToken()
+			authToken, err := authenticator.authToken(context.Background())
 			if err == nil && authToken != authenticator.expectedAuthToken(t) {
 				err = fmt.Errorf("Unexpected authToken `%s`. Expected `%s`.", authToken, authenticator.expectedAuthToken(t))
 			}
@@ -104,6 +105,73 @@ func TestAuthenticatorConcurrentAuthenticate(t *testing.T) {
 	}
 }
 
+// Make sure a failed token refresh does not leave the authenticator in a broken state.
+func TestAuthenticatorAuthTokenRefreshError(t *testing.T) {
+	provider := newMockAccessTokenProvider()
+	provider.refreshToken = func(token *accessToken) error {
+		return context.Canceled
+	}
+
+	authenticator := newMockAuthenticator(&accessToken{})
+	authenticator.accessTokenProvider = provider
+
+	if _, err := authenticator.authToken(context.Background()); err == nil {
+		t.Fatal("Expected error but got none.")
+	}
+
+	provider.refreshToken = func(token *accessToken) error {
+		*token = *newMockAccessToken(10 * time.Minute)
+		return nil
+	}
+
+	authToken, err := authenticator.authToken(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if authToken != "Bearer token" {
+		t.Fatalf("Unexpected authToken '%s'.", authToken)
+	}
+}
+
+// Make sure callers waiting for another caller's token refresh can be cancelled.
+func TestAuthenticatorAuthTokenCancelled(t *testing.T) {
+	refreshing := make(chan struct{})
+	release := make(chan struct{})
+
+	provider := newMockAccessTokenProvider()
+	provider.refreshToken = func(token *accessToken) error {
+		close(refreshing)
+		<-release
+		*token = *newMockAccessToken(10 * time.Minute)
+		return nil
+	}
+
+	authenticator := newMockAuthenticator(&accessToken{})
+	authenticator.accessTokenProvider = provider
+
+	errChan := make(chan error)
+	go func() {
+		_, err := authenticator.authToken(context.Background())
+		errChan <- err
+	}()
+
+	<-refreshing
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := authenticator.authToken(ctx); err != context.Canceled {
+		t.Fatalf("Unexpected error. Got: %v. Want: %v.", err, context.Canceled)
+	}
+
+	close(release)
+
+	if err := <-errChan; err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
 // Merges a slice of channels of errors into a single incoming channel of errors.
 func mergeErrorChans(cs []chan error) <-chan error {
 	var wg sync.WaitGroup
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"net/http"
+
+	_http "github.com/st3v/translator/http"
+)
+
+type subscriptionKeyAuthenticator struct {
+	key    string
+	region string
+}
+
+// NewSubscriptionKeyAuthenticator returns an authenticator that passes the
+// given subscription key directly to Microsoft API endpoints rather than
+// exchanging it for access tokens. The region of the Translator resource
+// must be given unless the resource is global.
+func NewSubscriptionKeyAuthenticator(subscriptionKey, region string) _http.Authenticator {
+	return &subscriptionKeyAuthenticator{
+		key:    subscriptionKey,
+		region: region,
+	}
+}
+
+func (a *subscriptionKeyAuthenticator) Authenticate(request *http.Request) error {
+	request.Header.Set("Ocp-Apim-Subscription-Key", a.key)
+
+	if a.region != "" {
+		request.Header.Set("Ocp-Apim-Subscription-Region", a.region)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSubscriptionKeyAuthenticator(t *testing.T) {
+	tests := []struct {
+		region string
+	}{
+		{""},
+		{"westeurope"},
+	}
+
+	for _, test := range tests {
+		request, err := http.NewRequest("POST", "http://example.com/translate", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = NewSubscriptionKeyAuthenticator("my-key", test.region).Authenticate(request)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if request.Header.Get("Ocp-Apim-Subscription-Key") != "my-key" {
+			t.Fatalf("Unexpected subscription key. Got: %s, Want: %s", request.Header.Get("Ocp-Apim-Subscription-Key"), "my-key")
+		}
+
+		if request.Header.Get("Ocp-Apim-Subscription-Region") != test.region {
+			t.Fatalf("Unexpected subscription region. Got: %s, Want: %s", request.Header.Get("Ocp-Apim-Subscription-Region"), test.region)
+		}
+
+		if request.Header.Get("Authorization") != "" {
+			t.Fatalf("Unexpected authorization header: %s", request.Header.Get("Authorization"))
+		}
+	}
+}
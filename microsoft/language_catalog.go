@@ -1,47 +1,59 @@
 package microsoft
 
 import (
-	"github.com/st3v/tracerr"
+	"context"
+	"time"
+
 	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
 )
 
 // The LanguageCatalog provides a slice of languages representing all
-// languages supported by Microsoft's Translation API.
+// languages supported by Microsoft's Translation API. Languages are named
+// in the given display locale. Refresh fetches the languages of all display
+// locales that have been requested so far.
 type LanguageCatalog interface {
-	Languages() ([]translator.Language, error)
+	Languages(ctx context.Context, displayLocale string) ([]translator.Language, error)
+	Refresh(ctx context.Context) error
 }
 
 type languageCatalog struct {
-	provider  LanguageProvider
-	languages []translator.Language
+	*translator.Catalog
+	provider LanguageProvider
 }
 
-func newLanguageCatalog(provider LanguageProvider) LanguageCatalog {
-	return &languageCatalog{
+func newLanguageCatalog(provider LanguageProvider, ttl time.Duration) LanguageCatalog {
+	c := &languageCatalog{
 		provider: provider,
 	}
+	c.Catalog = translator.NewCatalog(ttl, c.fetchLanguages)
+	return c
+}
+
+func (c *languageCatalog) Languages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return c.Catalog.Languages(ctx, codeMap.Code(displayLocale))
 }
 
-func (c *languageCatalog) Languages() ([]translator.Language, error) {
-	if c.languages == nil {
-		codes, err := c.provider.Codes()
-		if err != nil {
-			return nil, tracerr.Wrap(err)
-		}
-
-		names, err := c.provider.Names(codes)
-		if err != nil {
-			return nil, tracerr.Wrap(err)
-		}
-
-		for i := range codes {
-			c.languages = append(
-				c.languages,
-				translator.Language{
-					Code: codes[i],
-					Name: names[i],
-				})
-		}
+func (c *languageCatalog) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	codes, err := c.provider.Codes(ctx)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	names, err := c.provider.Names(ctx, codes, displayLocale)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	languages := make([]translator.Language, 0, len(codes))
+	for i := range codes {
+		languages = append(
+			languages,
+			translator.Language{
+				Code: codes[i],
+				Name: names[i],
+				Tag:  codeMap.Tag(codes[i]),
+			})
 	}
-	return c.languages, nil
+	return languages, nil
 }
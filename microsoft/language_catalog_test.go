@@ -1,6 +1,11 @@
 package microsoft
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/st3v/translator"
+)
 
 func TestLanguageCatalogLanguages(t *testing.T) {
 	expectedCodes := []string{"en", "de", "es", "ru", "jp"}
@@ -10,12 +15,12 @@ func TestLanguageCatalogLanguages(t *testing.T) {
 	languageProvider := newMockLanguageProvider()
 	languageProvider.codes = expectedCodes
 	languageProvider.names = expectedNames
-	languageCatalog := newLanguageCatalog(languageProvider)
+	languageCatalog := newLanguageCatalog(languageProvider, translator.DefaultCatalogTTL)
 
 	// retrieve languages from catalog 3 times
 	// make sure the catalog caches languages, i.e. it sends exactly one request to the language provider methods
 	for _ = range make([]int, 3) {
-		languages, err := languageCatalog.Languages()
+		languages, err := languageCatalog.Languages(context.Background(), "en")
 		if err != nil {
 			t.Fatalf("Unexpected error: %s", err.Error())
 		}
@@ -43,3 +48,57 @@ func TestLanguageCatalogLanguages(t *testing.T) {
 		}
 	}
 }
+
+func TestLanguageCatalogLanguagesPerLocale(t *testing.T) {
+	languageProvider := newMockLanguageProvider()
+	languageProvider.codes = []string{"de"}
+	languageProvider.names = []string{"Deutsch"}
+	languageCatalog := newLanguageCatalog(languageProvider, translator.DefaultCatalogTTL)
+
+	for _, locale := range []string{"de", "zh-Hant", "de", "zh-Hant"} {
+		if _, err := languageCatalog.Languages(context.Background(), locale); err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+	}
+
+	if languageProvider.callCounter["Names"] != 2 {
+		t.Fatalf("LanguagesProvider.Names should have been called exactly twice not %d times.", languageProvider.callCounter["Names"])
+	}
+
+	expectedLocales := []string{"de", "zh-CHT"}
+	for i := range expectedLocales {
+		if languageProvider.locales[i] != expectedLocales[i] {
+			t.Fatalf("Unexpected locale '%s'. Expected '%s'", languageProvider.locales[i], expectedLocales[i])
+		}
+	}
+}
+
+func TestLanguageCatalogRefresh(t *testing.T) {
+	languageProvider := newMockLanguageProvider()
+	languageProvider.codes = []string{"de"}
+	languageProvider.names = []string{"German"}
+	languageCatalog := newLanguageCatalog(languageProvider, translator.DefaultCatalogTTL)
+
+	if _, err := languageCatalog.Languages(context.Background(), "en"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	languageProvider.names = []string{"Deutsch"}
+
+	if err := languageCatalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	languages, err := languageCatalog.Languages(context.Background(), "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if languageProvider.callCounter["Names"] != 2 {
+		t.Fatalf("LanguagesProvider.Names should have been called exactly twice not %d times.", languageProvider.callCounter["Names"])
+	}
+
+	if languages[0].Name != "Deutsch" {
+		t.Fatalf("Unexpected language name '%s'. Expected 'Deutsch'", languages[0].Name)
+	}
+}
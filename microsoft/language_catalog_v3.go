@@ -0,0 +1,81 @@
+package microsoft
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+type languagesPayloadV3 struct {
+	Translation map[string]struct {
+		Name string
+	}
+}
+
+type languageCatalogV3 struct {
+	*translator.Catalog
+	router     RouterV3
+	httpClient http.Client
+}
+
+func newLanguageCatalogV3(httpClient http.Client, router RouterV3, ttl time.Duration) LanguageCatalog {
+	c := &languageCatalogV3{
+		router:     router,
+		httpClient: httpClient,
+	}
+	c.Catalog = translator.NewCatalog(ttl, c.fetchLanguages)
+	return c
+}
+
+func (c *languageCatalogV3) Languages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	return c.Catalog.Languages(ctx, codeMapV3.Code(displayLocale))
+}
+
+func (c *languageCatalogV3) fetchLanguages(ctx context.Context, displayLocale string) ([]translator.Language, error) {
+	params := url.Values{}
+	params.Set("api-version", apiVersionV3)
+	params.Set("scope", "translation")
+
+	// the v3 API expects the display locale in the Accept-Language header
+	ctx = http.ContextWithHeader(ctx, "Accept-Language", displayLocale)
+
+	response, err := c.httpClient.SendRequest(ctx, "GET", c.router.LanguageCodesURL()+"?"+params.Encode(), nil, "application/json")
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	if err := checkResponseV3(response); err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	payload := &languagesPayloadV3{}
+	if err := json.NewDecoder(response.Body).Decode(payload); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	codes := make([]string, 0, len(payload.Translation))
+	for code := range payload.Translation {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	languages := make([]translator.Language, 0, len(codes))
+	for _, code := range codes {
+		languages = append(
+			languages,
+			translator.Language{
+				Code: code,
+				Name: payload.Translation[code].Name,
+				Tag:  codeMapV3.Tag(code),
+			})
+	}
+	return languages, nil
+}
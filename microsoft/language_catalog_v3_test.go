@@ -0,0 +1,118 @@
+package microsoft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func TestLanguageCatalogV3Languages(t *testing.T) {
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "GET" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.URL.Path != "/languages" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("api-version") != "3.0" {
+			t.Fatalf("Unexpected `api-version` param in request: %s", r.URL.Query().Get("api-version"))
+		}
+
+		if r.URL.Query().Get("scope") != "translation" {
+			t.Fatalf("Unexpected `scope` param in request: %s", r.URL.Query().Get("scope"))
+		}
+
+		if r.Header.Get("Accept-Language") != "en" {
+			t.Fatalf("Unexpected Accept-Language header in request: %s", r.Header.Get("Accept-Language"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"translation":{
+			"fr":{"name":"French","nativeName":"Français","dir":"ltr"},
+			"de":{"name":"German","nativeName":"Deutsch","dir":"ltr"},
+			"en":{"name":"English","nativeName":"English","dir":"ltr"},
+			"pt-pt":{"name":"Portuguese (Portugal)","nativeName":"Português (Portugal)","dir":"ltr"}
+		}}`)
+	}))
+	defer server.Close()
+
+	catalog := newLanguageCatalogV3(_http.NewAuthenticatedClient(), newRouterV3(server.URL, ""), translator.DefaultCatalogTTL)
+
+	expected := []translator.Language{
+		{Code: "de", Name: "German", Tag: "de"},
+		{Code: "en", Name: "English", Tag: "en"},
+		{Code: "fr", Name: "French", Tag: "fr"},
+		{Code: "pt-pt", Name: "Portuguese (Portugal)", Tag: "pt-PT"},
+	}
+
+	for i := 0; i < 2; i++ {
+		actual, err := catalog.Languages(context.Background(), "en")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected languages. Want: %v. Got: %v.", expected, actual)
+		}
+	}
+
+	if requestCounter != 1 {
+		t.Fatalf("Expected 1 request but counted %d.", requestCounter)
+	}
+}
+
+func TestLanguageCatalogV3LanguagesIn(t *testing.T) {
+	names := map[string]string{
+		"de":    "Deutsch",
+		"en":    "German",
+		"pt-pt": "Alemão",
+	}
+
+	requestCounter := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := r.Header.Get("Accept-Language")
+		requestCounter[locale]++
+
+		name, ok := names[locale]
+		if !ok {
+			t.Fatalf("Unexpected Accept-Language header in request: %s", locale)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"translation":{"de":{"name":"%s","nativeName":"Deutsch","dir":"ltr"}}}`, name)
+	}))
+	defer server.Close()
+
+	catalog := newLanguageCatalogV3(_http.NewAuthenticatedClient(), newRouterV3(server.URL, ""), translator.DefaultCatalogTTL)
+
+	for _, locale := range []string{"de", "en", "pt-PT", "de", "en", "pt-PT"} {
+		actual, err := catalog.Languages(context.Background(), locale)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		expected := []translator.Language{{Code: "de", Name: names[codeMapV3.Code(locale)], Tag: "de"}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected languages for locale %s. Want: %v. Got: %v.", locale, expected, actual)
+		}
+	}
+
+	for locale, count := range requestCounter {
+		if count != 1 {
+			t.Fatalf("Expected 1 request for locale %s but counted %d.", locale, count)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package microsoft
+
+import "github.com/st3v/translator"
+
+// codeMap maps canonical BCP 47 tags to the language codes of version 2 of
+// Microsoft's API where the two differ.
+var codeMap = translator.CodeMap{
+	"nb":      "no",
+	"zh-Hans": "zh-CHS",
+	"zh-Hant": "zh-CHT",
+}
+
+// codeMapV3 maps canonical BCP 47 tags to the language codes of version 3 of
+// Microsoft's API where the two differ. Chinese is listed nonetheless, since
+// regional codes such as zh-TW canonicalize to a script.
+var codeMapV3 = translator.CodeMap{
+	"fr-CA":   "fr-ca",
+	"pt-PT":   "pt-pt",
+	"zh-Hans": "zh-Hans",
+	"zh-Hant": "zh-Hant",
+}
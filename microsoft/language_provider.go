@@ -1,8 +1,10 @@
 package microsoft
 
 import (
+	"context"
 	"encoding/xml"
 	"io/ioutil"
+	"net/url"
 	"strings"
 
 	"github.com/st3v/tracerr"
@@ -10,10 +12,11 @@ import (
 )
 
 // The LanguageProvider retrieves the names and codes of all languages
-// supported by Microsoft's Translation API.
+// supported by Microsoft's Translation API. Names are returned in the
+// given display locale.
 type LanguageProvider interface {
-	Codes() ([]string, error)
-	Names(codes []string) ([]string, error)
+	Codes(ctx context.Context) ([]string, error)
+	Names(ctx context.Context, codes []string, displayLocale string) ([]string, error)
 }
 
 type languageProvider struct {
@@ -21,20 +24,24 @@ type languageProvider struct {
 	httpClient http.Client
 }
 
-func newLanguageProvider(authenticator http.Authenticator, router Router) LanguageProvider {
+func newLanguageProvider(httpClient http.Client, router Router) LanguageProvider {
 	return &languageProvider{
 		router:     router,
-		httpClient: http.NewClient(authenticator),
+		httpClient: httpClient,
 	}
 }
 
-func (p *languageProvider) Names(codes []string) ([]string, error) {
+func (p *languageProvider) Names(ctx context.Context, codes []string, displayLocale string) ([]string, error) {
 	payload, _ := xml.Marshal(newXMLArrayOfStrings(codes))
-	uri := p.router.LanguageNamesURL() + "?locale=en"
+	uri := p.router.LanguageNamesURL() + "?locale=" + url.QueryEscape(displayLocale)
 
-	response, err := p.httpClient.SendRequest("POST", uri, strings.NewReader(string(payload)), "text/xml")
+	response, err := p.httpClient.SendRequest(ctx, "POST", uri, strings.NewReader(string(payload)), "text/xml")
 	if err != nil {
-		return nil, tracerr.Wrap(err)
+		return nil, http.WrapError(err)
+	}
+
+	if err := checkResponse(response); err != nil {
+		return nil, err
 	}
 
 	body, err := ioutil.ReadAll(response.Body)
@@ -51,10 +58,14 @@ func (p *languageProvider) Names(codes []string) ([]string, error) {
 	return result.Strings, nil
 }
 
-func (p *languageProvider) Codes() ([]string, error) {
-	response, err := p.httpClient.SendRequest("GET", p.router.LanguageCodesURL(), nil, "text/plain")
+func (p *languageProvider) Codes(ctx context.Context) ([]string, error) {
+	response, err := p.httpClient.SendRequest(ctx, "GET", p.router.LanguageCodesURL(), nil, "text/plain")
 	if err != nil {
-		return nil, tracerr.Wrap(err)
+		return nil, http.WrapError(err)
+	}
+
+	if err := checkResponse(response); err != nil {
+		return nil, err
 	}
 
 	body, err := ioutil.ReadAll(response.Body)
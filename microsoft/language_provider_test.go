@@ -1,12 +1,14 @@
 package microsoft
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	_http "github.com/st3v/translator/http"
 )
@@ -43,7 +45,7 @@ func TestLanguageProviderCodes(t *testing.T) {
 		httpClient: _http.NewAuthenticatedClient(),
 	}
 
-	actualCodes, err := languageProvider.Codes()
+	actualCodes, err := languageProvider.Codes(context.Background())
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err)
 	}
@@ -72,6 +74,10 @@ func TestLanguageProviderNames(t *testing.T) {
 			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
 		}
 
+		if r.URL.Query().Get("locale") != "en" {
+			t.Fatalf("Unexpected locale in request. Got: %s. Want: en.", r.URL.Query().Get("locale"))
+		}
+
 		body, err := ioutil.ReadAll(r.Body)
 		defer r.Body.Close()
 		if err != nil {
@@ -113,7 +119,7 @@ func TestLanguageProviderNames(t *testing.T) {
 		httpClient: _http.NewAuthenticatedClient(),
 	}
 
-	actualNames, err := languageProvider.Names(expectedCodes)
+	actualNames, err := languageProvider.Names(context.Background(), expectedCodes, "en")
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err.Error())
 	}
@@ -129,6 +135,72 @@ func TestLanguageProviderNames(t *testing.T) {
 	}
 }
 
+// Make sure the XML payload is sent again when a request is retried.
+func TestLanguageProviderNamesRetry(t *testing.T) {
+	expectedCodes := []string{"en", "de"}
+	expectedNames := []string{"English", "German"}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		actualCodes := &xmlArrayOfStrings{}
+		if err := xml.Unmarshal(body, &actualCodes); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body in attempt %d: %s", requestCounter, err.Error())
+		}
+
+		if len(actualCodes.Strings) != len(expectedCodes) {
+			t.Fatalf("Unexpected number of languages codes in request: %q", actualCodes.Strings)
+		}
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		response, err := xml.Marshal(newXMLArrayOfStrings(expectedNames))
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+
+		fmt.Fprint(w, string(response))
+		return
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.languageNamesURL = server.URL
+
+	languageProvider := &languageProvider{
+		router: router,
+		httpClient: _http.NewAuthenticatedClient(
+			_http.WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+		),
+	}
+
+	actualNames, err := languageProvider.Names(context.Background(), expectedCodes, "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 requests but counted %d.", requestCounter)
+	}
+
+	if len(actualNames) != len(expectedNames) {
+		t.Fatalf("Unexpected number of languages names: %q", actualNames)
+	}
+}
+
 func newMockLanguageProvider() *mockLanguageProvider {
 	return &mockLanguageProvider{
 		callCounter: make(map[string]int),
@@ -141,14 +213,16 @@ type mockLanguageProvider struct {
 	callCounter map[string]int
 	codes       []string
 	names       []string
+	locales     []string
 }
 
-func (p *mockLanguageProvider) Codes() ([]string, error) {
+func (p *mockLanguageProvider) Codes(ctx context.Context) ([]string, error) {
 	p.callCounter["Codes"]++
 	return p.codes, nil
 }
 
-func (p *mockLanguageProvider) Names(codes []string) ([]string, error) {
+func (p *mockLanguageProvider) Names(ctx context.Context, codes []string, displayLocale string) ([]string, error) {
 	p.callCounter["Names"]++
+	p.locales = append(p.locales, displayLocale)
 	return p.names, nil
 }
@@ -0,0 +1,180 @@
+package microsoft
+
+import (
+	nethttp "net/http"
+	"time"
+
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+	msauth "github.com/st3v/translator/microsoft/auth"
+)
+
+// Option configures the Translator returned by NewTranslator.
+type Option func(*options)
+
+type options struct {
+	baseURL       string
+	authURL       string
+	region        string
+	tokenAuth     bool
+	authenticator http.Authenticator
+	retryPolicy   http.RetryPolicy
+	catalogTTL    time.Duration
+	minConfidence float64
+	clientOptions []http.ClientOption
+}
+
+func newOptions(subscriptionKey string, opts []Option) *options {
+	o := applyOptions(serviceURL, opts)
+
+	if o.authenticator == nil {
+		o.authenticator = msauth.NewAuthenticator(subscriptionKey, o.authURL)
+	}
+
+	return o
+}
+
+func newOptionsV3(subscriptionKey string, opts []Option) *options {
+	o := applyOptions(serviceURLV3, opts)
+
+	if o.authenticator == nil {
+		if o.tokenAuth {
+			o.authenticator = msauth.NewAuthenticator(subscriptionKey, o.authURL)
+		} else {
+			o.authenticator = msauth.NewSubscriptionKeyAuthenticator(subscriptionKey, o.region)
+		}
+	}
+
+	return o
+}
+
+func applyOptions(baseURL string, opts []Option) *options {
+	o := &options{
+		baseURL:     baseURL,
+		authURL:     authURL,
+		retryPolicy: http.DefaultRetryPolicy(),
+		catalogTTL:  translator.DefaultCatalogTTL,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o *options) httpClient() http.Client {
+	clientOptions := append([]http.ClientOption{http.WithRetryPolicy(o.retryPolicy)}, o.clientOptions...)
+	return http.NewClient(o.authenticator, clientOptions...)
+}
+
+// WithHTTPClient makes the Translator send requests by means of the given
+// net/http client, e.g. to use a proxy or a custom transport.
+func WithHTTPClient(httpClient *nethttp.Client) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithHTTPClient(httpClient))
+	}
+}
+
+// WithBaseURL makes the Translator send requests to the given base URL
+// instead of https://api.microsofttranslator.com/v2/Http.svc/, or
+// https://api.cognitive.microsofttranslator.com/ in case of NewTranslatorV3.
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		o.baseURL = url
+	}
+}
+
+// WithAuthURL makes the Translator obtain access tokens from the given URL
+// instead of https://api.cognitive.microsoft.com/sts/v1.0/issueToken.
+// The option has no effect in combination with WithAuthenticator.
+func WithAuthURL(url string) Option {
+	return func(o *options) {
+		o.authURL = url
+	}
+}
+
+// WithRegion sets the region of the Translator resource, e.g. westeurope,
+// which version 3 of the API expects in the Ocp-Apim-Subscription-Region
+// header when authenticating with the subscription key. Only applies to
+// NewTranslatorV3 and has no effect in combination with
+// WithTokenAuthentication or WithAuthenticator.
+func WithRegion(region string) Option {
+	return func(o *options) {
+		o.region = region
+	}
+}
+
+// WithTokenAuthentication makes the Translator returned by NewTranslatorV3
+// exchange the subscription key for access tokens, like NewTranslator does.
+// Regional resources require a regional auth URL, see WithAuthURL.
+func WithTokenAuthentication() Option {
+	return func(o *options) {
+		o.tokenAuth = true
+	}
+}
+
+// WithTimeout limits the time a single attempt to send a request may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithTimeout(timeout))
+	}
+}
+
+// WithRateLimiter makes the Translator wait for the given limiter before
+// every request it sends, including retries and language catalog fetches.
+// Access tokens are only requested while authenticating a request that the
+// limiter has admitted.
+func WithRateLimiter(limiter *http.RateLimiter) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithRateLimiter(limiter))
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, http.WithUserAgent(userAgent))
+	}
+}
+
+// WithAuthenticator replaces the default authenticator that obtains
+// access tokens for, or in case of NewTranslatorV3 directly passes on,
+// the subscription key.
+func WithAuthenticator(authenticator http.Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithRetryPolicy replaces http.DefaultRetryPolicy.
+func WithRetryPolicy(policy http.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithCatalogTTL sets the time for which the Translator caches the supported
+// languages before it refreshes them in the background. Defaults to
+// translator.DefaultCatalogTTL. Languages never expire if the TTL is not
+// positive.
+func WithCatalogTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogTTL = ttl
+	}
+}
+
+// WithMinConfidence makes the Translator detect the source language of texts
+// that are translated without one by means of a separate detection request,
+// and fail with translator.ErrLowConfidence if the score of the detection is
+// below the given threshold between 0 and 1. By default, the source language
+// is detected as part of the translation request regardless of its score.
+// Batch translations are not affected. Only supported by NewTranslatorV3,
+// version 2 of the API does not score detections. Translations of the
+// Translator returned by NewTranslator therefore fail with a
+// *translator.OptionError if this option is set.
+func WithMinConfidence(confidence float64) Option {
+	return func(o *options) {
+		o.minConfidence = confidence
+	}
+}
@@ -0,0 +1,157 @@
+package microsoft
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_http "github.com/st3v/translator/http"
+)
+
+func TestNewTranslatorOptions(t *testing.T) {
+	expectedSubscriptionKey := "my-subscription-key"
+	expectedUserAgent := "fake-user-agent"
+	expectedTranslation := "Hallo Welt!"
+
+	requestCounter := 0
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/issueToken", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != expectedSubscriptionKey {
+			t.Fatalf("Unexpected subscription key: %s", r.Header.Get("Ocp-Apim-Subscription-Key"))
+		}
+
+		fmt.Fprint(w, "fake-token")
+	})
+
+	mux.HandleFunc("/v2/Http.svc/Translate", func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		if r.Header.Get("User-Agent") != expectedUserAgent {
+			t.Fatalf("Unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+
+		if requestCounter == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		response, err := xml.Marshal(newXMLString(expectedTranslation))
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, string(response))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	translator := NewTranslator(
+		expectedSubscriptionKey,
+		WithBaseURL(server.URL+"/v2/Http.svc"),
+		WithAuthURL(server.URL+"/issueToken"),
+		WithHTTPClient(server.Client()),
+		WithTimeout(time.Second),
+		WithUserAgent(expectedUserAgent),
+		WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}),
+	)
+
+	actualTranslation, err := translator.Translate("Hello World!", "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation: %s. Expected: %s.", actualTranslation, expectedTranslation)
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 requests but counted %d.", requestCounter)
+	}
+}
+
+func TestNewTranslatorV3Options(t *testing.T) {
+	expectedSubscriptionKey := "my-subscription-key"
+	expectedRegion := "westeurope"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != expectedSubscriptionKey {
+			t.Fatalf("Unexpected subscription key: %s", r.Header.Get("Ocp-Apim-Subscription-Key"))
+		}
+
+		if r.Header.Get("Ocp-Apim-Subscription-Region") != expectedRegion {
+			t.Fatalf("Unexpected subscription region: %s", r.Header.Get("Ocp-Apim-Subscription-Region"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hallo Welt!","to":"de"}]}]`)
+	}))
+	defer server.Close()
+
+	translator := NewTranslatorV3(
+		expectedSubscriptionKey,
+		WithBaseURL(server.URL),
+		WithRegion(expectedRegion),
+		WithHTTPClient(server.Client()),
+	)
+
+	if _, err := translator.Translate("Hello World!", "en", "de"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestNewTranslatorV3TokenAuthentication(t *testing.T) {
+	expectedSubscriptionKey := "my-subscription-key"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/issueToken", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != expectedSubscriptionKey {
+			t.Fatalf("Unexpected subscription key: %s", r.Header.Get("Ocp-Apim-Subscription-Key"))
+		}
+
+		fmt.Fprint(w, "fake-token")
+	})
+
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fake-token" {
+			t.Fatalf("Unexpected authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "" {
+			t.Fatalf("Unexpected subscription key: %s", r.Header.Get("Ocp-Apim-Subscription-Key"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hallo Welt!","to":"de"}]}]`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	translator := NewTranslatorV3(
+		expectedSubscriptionKey,
+		WithBaseURL(server.URL),
+		WithAuthURL(server.URL+"/issueToken"),
+		WithTokenAuthentication(),
+		WithHTTPClient(server.Client()),
+	)
+
+	if _, err := translator.Translate("Hello World!", "en", "de"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+}
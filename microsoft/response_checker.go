@@ -0,0 +1,104 @@
+package microsoft
+
+import (
+	"encoding/json"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+const provider = "microsoft"
+
+var (
+	errorReasonPattern    = regexp.MustCompile(`<h1>(.*?)</h1>`)
+	errorParameterPattern = regexp.MustCompile(`<p>Parameter: (.*?)</p>`)
+	errorMessagePattern   = regexp.MustCompile(`(?s)<p>Message: (.*?)(?:&#xD;|\r|\n|</p>)`)
+)
+
+// checkResponse returns a translator.APIError if the given response
+// indicates an error. The body of erroneous responses is consumed
+// and closed.
+var checkResponse = func(response *http.Response) error {
+	if response.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	err := _http.NewAPIError(provider, response)
+
+	if m := errorReasonPattern.FindSubmatch(body); m != nil {
+		err.Reason = html.UnescapeString(string(m[1]))
+	}
+
+	if m := errorMessagePattern.FindSubmatch(body); m != nil {
+		err.Message = strings.TrimSpace(html.UnescapeString(string(m[1])))
+	} else if len(body) > 0 {
+		err.Message = strings.TrimSpace(string(body))
+	}
+
+	switch response.StatusCode {
+	case http.StatusBadRequest:
+		if m := errorParameterPattern.FindSubmatch(body); m != nil {
+			switch string(m[1]) {
+			case "from", "to", "locale":
+				err.Err = translator.ErrUnsupportedLanguage
+			}
+		}
+	case http.StatusForbidden:
+		err.Err = translator.ErrQuotaExceeded
+	}
+
+	return err
+}
+
+type errorPayloadV3 struct {
+	Error struct {
+		Code    int
+		Message string
+	}
+}
+
+// checkResponseV3 is the equivalent of checkResponse for version 3 of
+// Microsoft's API, which describes errors by means of a JSON body.
+// See https://docs.microsoft.com/azure/cognitive-services/translator/reference/v3-0-reference#errors
+var checkResponseV3 = func(response *http.Response) error {
+	if response.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	err := _http.NewAPIError(provider, response)
+
+	payload := &errorPayloadV3{}
+	if json.Unmarshal(body, payload) != nil || payload.Error.Code == 0 {
+		err.Message = strings.TrimSpace(string(body))
+		return err
+	}
+
+	err.Code = strconv.Itoa(payload.Error.Code)
+	err.Message = payload.Error.Message
+
+	switch payload.Error.Code {
+	case 400003, 400004, 400006, 400018, 400019, 400023, 400035, 400036:
+		// invalid language, invalid target script, invalid combination of
+		// language and script, invalid source script, language not
+		// supported, invalid language pair, invalid source language,
+		// invalid target language
+		err.Err = translator.ErrUnsupportedLanguage
+	case 403001:
+		// free tier quota exceeded
+		err.Err = translator.ErrQuotaExceeded
+	}
+
+	return err
+}
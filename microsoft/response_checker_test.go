@@ -0,0 +1,222 @@
+package microsoft
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/st3v/translator"
+)
+
+func TestCheckResponseOK(t *testing.T) {
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("fake-body")),
+	}
+
+	if err := checkResponse(response); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	body, _ := ioutil.ReadAll(response.Body)
+	if string(body) != "fake-body" {
+		t.Fatalf("Response body should not have been consumed. Got: '%s'", string(body))
+	}
+}
+
+func TestCheckResponseAPIError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		body       string
+		reason     string
+		message    string
+		retryable  bool
+		err        error
+	}{
+		{
+			http.StatusBadRequest,
+			"<html><body><h1>Argument Exception</h1><p>Method: Translate()</p><p>Parameter: to</p>" +
+				"<p>Message: 'to' must be a valid language&#xD;\nParameter name: to</p></body></html>",
+			"Argument Exception",
+			"'to' must be a valid language",
+			false,
+			translator.ErrUnsupportedLanguage,
+		},
+		{
+			http.StatusBadRequest,
+			"<html><body><h1>Argument Exception</h1><p>Method: Translate()</p><p>Parameter: text</p>" +
+				"<p>Message: text is too long</p></body></html>",
+			"Argument Exception",
+			"text is too long",
+			false,
+			nil,
+		},
+		{
+			http.StatusUnauthorized,
+			"<html><body><h1>Argument Exception</h1><p>Message: The incoming token has expired.</p></body></html>",
+			"Argument Exception",
+			"The incoming token has expired.",
+			false,
+			translator.ErrUnauthorized,
+		},
+		{
+			http.StatusForbidden,
+			"Out of call volume quota.",
+			"",
+			"Out of call volume quota.",
+			false,
+			translator.ErrQuotaExceeded,
+		},
+		{
+			http.StatusServiceUnavailable,
+			"",
+			"",
+			"503 Service Unavailable",
+			true,
+			nil,
+		},
+	} {
+		response := &http.Response{
+			StatusCode: tc.statusCode,
+			Status:     "503 Service Unavailable",
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+
+		err := checkResponse(response)
+
+		var apiErr *translator.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected APIError. Got: %#v", err)
+		}
+
+		if apiErr.Provider != "microsoft" {
+			t.Errorf("Unexpected provider: %s", apiErr.Provider)
+		}
+
+		if apiErr.Reason != tc.reason {
+			t.Errorf("Unexpected reason. Want: %q. Got: %q.", tc.reason, apiErr.Reason)
+		}
+
+		if apiErr.Message != tc.message {
+			t.Errorf("Unexpected message. Want: %q. Got: %q.", tc.message, apiErr.Message)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Errorf("Unexpected retryable flag for status %d. Want: %t. Got: %t.", tc.statusCode, tc.retryable, apiErr.Retryable)
+		}
+
+		if apiErr.Err != tc.err {
+			t.Errorf("Unexpected sentinel error for status %d. Want: %v. Got: %v.", tc.statusCode, tc.err, apiErr.Err)
+		}
+	}
+}
+
+func TestCheckResponseV3APIError(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		body       string
+		code       string
+		message    string
+		retryable  bool
+		err        error
+	}{
+		{
+			http.StatusBadRequest,
+			`{"error":{"code":400036,"message":"The target language is not valid."}}`,
+			"400036",
+			"The target language is not valid.",
+			false,
+			translator.ErrUnsupportedLanguage,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":{"code":400004,"message":"A target script specifier (\"To script\") is missing or invalid."}}`,
+			"400004",
+			"A target script specifier (\"To script\") is missing or invalid.",
+			false,
+			translator.ErrUnsupportedLanguage,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":{"code":400023,"message":"One of the specified language pair is not valid."}}`,
+			"400023",
+			"One of the specified language pair is not valid.",
+			false,
+			translator.ErrUnsupportedLanguage,
+		},
+		{
+			http.StatusBadRequest,
+			`{"error":{"code":400050,"message":"The input text is too long."}}`,
+			"400050",
+			"The input text is too long.",
+			false,
+			nil,
+		},
+		{
+			http.StatusUnauthorized,
+			`{"error":{"code":401000,"message":"The request is not authorized because credentials are missing or invalid."}}`,
+			"401000",
+			"The request is not authorized because credentials are missing or invalid.",
+			false,
+			translator.ErrUnauthorized,
+		},
+		{
+			http.StatusForbidden,
+			`{"error":{"code":403001,"message":"The operation is not allowed because the subscription has exceeded its free quota."}}`,
+			"403001",
+			"The operation is not allowed because the subscription has exceeded its free quota.",
+			false,
+			translator.ErrQuotaExceeded,
+		},
+		{
+			http.StatusTooManyRequests,
+			`{"error":{"code":429001,"message":"The server rejected the request because the client has exceeded request limits."}}`,
+			"429001",
+			"The server rejected the request because the client has exceeded request limits.",
+			true,
+			translator.ErrQuotaExceeded,
+		},
+		{
+			http.StatusServiceUnavailable,
+			"Service Unavailable",
+			"",
+			"Service Unavailable",
+			true,
+			nil,
+		},
+	} {
+		response := &http.Response{
+			StatusCode: tc.statusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+
+		err := checkResponseV3(response)
+
+		apiErr := &translator.APIError{}
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Unexpected error type. Got: %T, Want: *translator.APIError", err)
+		}
+
+		if apiErr.Code != tc.code {
+			t.Fatalf("Unexpected code. Got: %s, Want: %s", apiErr.Code, tc.code)
+		}
+
+		if apiErr.Message != tc.message {
+			t.Fatalf("Unexpected message. Got: %s, Want: %s", apiErr.Message, tc.message)
+		}
+
+		if apiErr.Retryable != tc.retryable {
+			t.Fatalf("Unexpected retryable flag. Got: %t, Want: %t", apiErr.Retryable, tc.retryable)
+		}
+
+		if !errors.Is(err, tc.err) && tc.err != nil {
+			t.Fatalf("Unexpected error. Got: %v, Want: %v", apiErr.Err, tc.err)
+		}
+
+		if tc.err == nil && apiErr.Err != nil {
+			t.Fatalf("Unexpected sentinel error: %v", apiErr.Err)
+		}
+	}
+}
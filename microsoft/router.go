@@ -1,12 +1,10 @@
 package microsoft
 
+import "strings"
+
 const (
-	authURL          = "https://api.cognitive.microsoft.com/sts/v1.0/issueToken"
-	serviceURL       = "https://api.microsofttranslator.com/v2/Http.svc/"
-	translationURL   = serviceURL + "Translate"
-	detectURL        = serviceURL + "Detect"
-	languageNamesURL = serviceURL + "GetLanguageNames"
-	languageCodesURL = serviceURL + "GetLanguagesForTranslate"
+	authURL    = "https://api.cognitive.microsoft.com/sts/v1.0/issueToken"
+	serviceURL = "https://api.microsofttranslator.com/v2/Http.svc/"
 )
 
 // The Router provides necessary URLs to communicate with
@@ -14,33 +12,53 @@ const (
 type Router interface {
 	AuthURL() string
 	TranslationURL() string
+	TranslateArrayURL() string
 	DetectURL() string
+	DetectArrayURL() string
 	LanguageNamesURL() string
 	LanguageCodesURL() string
 }
 
-type router struct{}
+type router struct {
+	authURL    string
+	serviceURL string
+}
+
+func newRouter(serviceURL, authURL string) Router {
+	if !strings.HasSuffix(serviceURL, "/") {
+		serviceURL += "/"
+	}
 
-func newRouter() Router {
-	return &router{}
+	return &router{
+		authURL:    authURL,
+		serviceURL: serviceURL,
+	}
 }
 
 func (r *router) AuthURL() string {
-	return authURL
+	return r.authURL
 }
 
 func (r *router) TranslationURL() string {
-	return translationURL
+	return r.serviceURL + "Translate"
+}
+
+func (r *router) TranslateArrayURL() string {
+	return r.serviceURL + "TranslateArray"
 }
 
 func (r *router) DetectURL() string {
-	return detectURL
+	return r.serviceURL + "Detect"
+}
+
+func (r *router) DetectArrayURL() string {
+	return r.serviceURL + "DetectArray"
 }
 
 func (r *router) LanguageNamesURL() string {
-	return languageNamesURL
+	return r.serviceURL + "GetLanguageNames"
 }
 
 func (r *router) LanguageCodesURL() string {
-	return languageCodesURL
+	return r.serviceURL + "GetLanguagesForTranslate"
 }
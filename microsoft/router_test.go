@@ -3,7 +3,7 @@ package microsoft
 import "testing"
 
 func TestRouterAuthURL(t *testing.T) {
-	router := newRouter()
+	router := newRouter(serviceURL, authURL)
 
 	expectedURL := "https://api.cognitive.microsoft.com/sts/v1.0/issueToken"
 
@@ -15,7 +15,7 @@ func TestRouterAuthURL(t *testing.T) {
 }
 
 func TestRouterTranslationURL(t *testing.T) {
-	router := newRouter()
+	router := newRouter(serviceURL, authURL)
 
 	expectedURL := "https://api.microsofttranslator.com/v2/Http.svc/Translate"
 
@@ -26,8 +26,32 @@ func TestRouterTranslationURL(t *testing.T) {
 	}
 }
 
+func TestRouterTranslateArrayURL(t *testing.T) {
+	router := newRouter(serviceURL, authURL)
+
+	expectedURL := "https://api.microsofttranslator.com/v2/Http.svc/TranslateArray"
+
+	actualURL := router.TranslateArrayURL()
+
+	if actualURL != expectedURL {
+		t.Fatalf("Unexpected TranslateArrayURL. Want: %q. Got: %q.", expectedURL, actualURL)
+	}
+}
+
+func TestRouterDetectArrayURL(t *testing.T) {
+	router := newRouter(serviceURL, authURL)
+
+	expectedURL := "https://api.microsofttranslator.com/v2/Http.svc/DetectArray"
+
+	actualURL := router.DetectArrayURL()
+
+	if actualURL != expectedURL {
+		t.Fatalf("Unexpected DetectArrayURL. Want: %q. Got: %q.", expectedURL, actualURL)
+	}
+}
+
 func TestRouterLanguageNamesURL(t *testing.T) {
-	router := newRouter()
+	router := newRouter(serviceURL, authURL)
 
 	expectedURL := "https://api.microsofttranslator.com/v2/Http.svc/GetLanguageNames"
 
@@ -39,7 +63,7 @@ func TestRouterLanguageNamesURL(t *testing.T) {
 }
 
 func TestRouterLanguageCodesURL(t *testing.T) {
-	router := newRouter()
+	router := newRouter(serviceURL, authURL)
 
 	expectedURL := "https://api.microsofttranslator.com/v2/Http.svc/GetLanguagesForTranslate"
 
@@ -50,22 +74,38 @@ func TestRouterLanguageCodesURL(t *testing.T) {
 	}
 }
 
+func TestRouterServiceURL(t *testing.T) {
+	router := newRouter("http://localhost:8080/v2/Http.svc", "http://localhost:8080/issueToken")
+
+	if want, have := "http://localhost:8080/issueToken", router.AuthURL(); have != want {
+		t.Fatalf("Unexpected AuthURL. Want: %q. Got: %q.", want, have)
+	}
+
+	if want, have := "http://localhost:8080/v2/Http.svc/Translate", router.TranslationURL(); have != want {
+		t.Fatalf("Unexpected TranslationURL. Want: %q. Got: %q.", want, have)
+	}
+}
+
 func newMockRouter() *mockRouter {
 	return &mockRouter{
-		authURL:          "auth",
-		translationURL:   "translation",
-		languageNamesURL: "languages_names",
-		languageCodesURL: "languages_codes",
-		detectURL:        "detect",
+		authURL:           "auth",
+		translationURL:    "translation",
+		translateArrayURL: "translate_array",
+		languageNamesURL:  "languages_names",
+		languageCodesURL:  "languages_codes",
+		detectURL:         "detect",
+		detectArrayURL:    "detect_array",
 	}
 }
 
 type mockRouter struct {
-	authURL          string
-	translationURL   string
-	languageNamesURL string
-	languageCodesURL string
-	detectURL        string
+	authURL           string
+	translationURL    string
+	translateArrayURL string
+	languageNamesURL  string
+	languageCodesURL  string
+	detectURL         string
+	detectArrayURL    string
 }
 
 func (m *mockRouter) AuthURL() string {
@@ -76,6 +116,10 @@ func (m *mockRouter) TranslationURL() string {
 	return m.translationURL
 }
 
+func (m *mockRouter) TranslateArrayURL() string {
+	return m.translateArrayURL
+}
+
 func (m *mockRouter) LanguageNamesURL() string {
 	return m.languageNamesURL
 }
@@ -87,3 +131,7 @@ func (m *mockRouter) LanguageCodesURL() string {
 func (m *mockRouter) DetectURL() string {
 	return m.detectURL
 }
+
+func (m *mockRouter) DetectArrayURL() string {
+	return m.detectArrayURL
+}
@@ -0,0 +1,67 @@
+package microsoft
+
+import "strings"
+
+const serviceURLV3 = "https://api.cognitive.microsofttranslator.com/"
+
+// The RouterV3 provides the URLs of version 3 of Microsoft's API. Endpoints
+// of version 3 accept multiple texts per request, hence TranslationURL and
+// TranslateArrayURL, DetectURL and DetectArrayURL, as well as LanguageNamesURL
+// and LanguageCodesURL return the same URLs respectively.
+type RouterV3 interface {
+	Router
+	TransliterationURL() string
+	DictionaryLookupURL() string
+}
+
+type routerV3 struct {
+	authURL    string
+	serviceURL string
+}
+
+func newRouterV3(serviceURL, authURL string) RouterV3 {
+	if !strings.HasSuffix(serviceURL, "/") {
+		serviceURL += "/"
+	}
+
+	return &routerV3{
+		authURL:    authURL,
+		serviceURL: serviceURL,
+	}
+}
+
+func (r *routerV3) AuthURL() string {
+	return r.authURL
+}
+
+func (r *routerV3) TranslationURL() string {
+	return r.serviceURL + "translate"
+}
+
+func (r *routerV3) TranslateArrayURL() string {
+	return r.serviceURL + "translate"
+}
+
+func (r *routerV3) DetectURL() string {
+	return r.serviceURL + "detect"
+}
+
+func (r *routerV3) DetectArrayURL() string {
+	return r.serviceURL + "detect"
+}
+
+func (r *routerV3) LanguageNamesURL() string {
+	return r.serviceURL + "languages"
+}
+
+func (r *routerV3) LanguageCodesURL() string {
+	return r.serviceURL + "languages"
+}
+
+func (r *routerV3) TransliterationURL() string {
+	return r.serviceURL + "transliterate"
+}
+
+func (r *routerV3) DictionaryLookupURL() string {
+	return r.serviceURL + "dictionary/lookup"
+}
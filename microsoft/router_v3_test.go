@@ -0,0 +1,35 @@
+package microsoft
+
+import "testing"
+
+func TestRouterV3URLs(t *testing.T) {
+	router := newRouterV3(serviceURLV3, authURL)
+
+	for _, tc := range []struct {
+		name   string
+		actual string
+		want   string
+	}{
+		{"AuthURL", router.AuthURL(), "https://api.cognitive.microsoft.com/sts/v1.0/issueToken"},
+		{"TranslationURL", router.TranslationURL(), "https://api.cognitive.microsofttranslator.com/translate"},
+		{"TranslateArrayURL", router.TranslateArrayURL(), "https://api.cognitive.microsofttranslator.com/translate"},
+		{"DetectURL", router.DetectURL(), "https://api.cognitive.microsofttranslator.com/detect"},
+		{"DetectArrayURL", router.DetectArrayURL(), "https://api.cognitive.microsofttranslator.com/detect"},
+		{"LanguageNamesURL", router.LanguageNamesURL(), "https://api.cognitive.microsofttranslator.com/languages"},
+		{"LanguageCodesURL", router.LanguageCodesURL(), "https://api.cognitive.microsofttranslator.com/languages"},
+		{"TransliterationURL", router.TransliterationURL(), "https://api.cognitive.microsofttranslator.com/transliterate"},
+		{"DictionaryLookupURL", router.DictionaryLookupURL(), "https://api.cognitive.microsofttranslator.com/dictionary/lookup"},
+	} {
+		if tc.actual != tc.want {
+			t.Fatalf("Unexpected %s. Want: %q. Got: %q.", tc.name, tc.want, tc.actual)
+		}
+	}
+}
+
+func TestRouterV3ServiceURL(t *testing.T) {
+	router := newRouterV3("http://localhost:8080", "http://localhost:8080/issueToken")
+
+	if want, have := "http://localhost:8080/translate", router.TranslationURL(); have != want {
+		t.Fatalf("Unexpected TranslationURL. Want: %q. Got: %q.", want, have)
+	}
+}
@@ -0,0 +1,108 @@
+package microsoft
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+type scriptsPayloadV3 struct {
+	Transliteration map[string]struct {
+		Scripts []struct {
+			Code      string
+			Name      string
+			ToScripts []struct {
+				Code string
+			}
+		}
+	}
+}
+
+// The scriptCatalogV3 caches the scripts of all languages that version 3 of
+// Microsoft's API is able to transliterate in a translator.Catalog, i.e. the
+// scripts are refreshed like the supported languages.
+type scriptCatalogV3 struct {
+	router     RouterV3
+	httpClient http.Client
+	catalog    *translator.Catalog
+}
+
+func newScriptCatalogV3(httpClient http.Client, router RouterV3, ttl time.Duration) *scriptCatalogV3 {
+	c := &scriptCatalogV3{
+		router:     router,
+		httpClient: httpClient,
+	}
+
+	c.catalog = translator.NewValueCatalog(ttl, func(ctx context.Context, _ string) (interface{}, error) {
+		return c.fetchScripts(ctx)
+	})
+
+	return c
+}
+
+// Scripts returns the scripts of the language with the given code of the
+// API, or translator.ErrUnsupportedLanguage if the API is unable to
+// transliterate the language.
+func (c *scriptCatalogV3) Scripts(ctx context.Context, language string) ([]translator.Script, error) {
+	value, err := c.catalog.Value(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, ok := value.(map[string][]translator.Script)[language]
+	if !ok {
+		return nil, translator.ErrUnsupportedLanguage
+	}
+
+	return scripts, nil
+}
+
+// Refresh fetches the scripts again if they have been requested before.
+func (c *scriptCatalogV3) Refresh(ctx context.Context) error {
+	return c.catalog.Refresh(ctx)
+}
+
+func (c *scriptCatalogV3) fetchScripts(ctx context.Context) (map[string][]translator.Script, error) {
+	params := url.Values{}
+	params.Set("api-version", apiVersionV3)
+	params.Set("scope", "transliteration")
+
+	response, err := c.httpClient.SendRequest(ctx, "GET", c.router.LanguageCodesURL()+"?"+params.Encode(), nil, "application/json")
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	if err := checkResponseV3(response); err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	payload := &scriptsPayloadV3{}
+	if err := json.NewDecoder(response.Body).Decode(payload); err != nil {
+		return nil, tracerr.Wrap(err)
+	}
+
+	scripts := make(map[string][]translator.Script, len(payload.Transliteration))
+	for code, language := range payload.Transliteration {
+		for _, s := range language.Scripts {
+			script := translator.Script{
+				Code: s.Code,
+				Name: s.Name,
+			}
+
+			for _, to := range s.ToScripts {
+				script.ToScripts = append(script.ToScripts, to.Code)
+			}
+
+			scripts[code] = append(scripts[code], script)
+		}
+	}
+
+	return scripts, nil
+}
@@ -0,0 +1,174 @@
+package microsoft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func TestScriptCatalogV3Scripts(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCounter, 1)
+
+		if r.Method != "GET" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.URL.Path != "/languages" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("api-version") != "3.0" {
+			t.Fatalf("Unexpected `api-version` param in request: %s", r.URL.Query().Get("api-version"))
+		}
+
+		if r.URL.Query().Get("scope") != "transliteration" {
+			t.Fatalf("Unexpected `scope` param in request: %s", r.URL.Query().Get("scope"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"transliteration":{
+			"ja":{"name":"Japanese","nativeName":"日本語","scripts":[
+				{"code":"Jpan","name":"Japanese","nativeName":"日本語","dir":"ltr","toScripts":[
+					{"code":"Latn","name":"Latin","nativeName":"Latin","dir":"ltr"}
+				]},
+				{"code":"Latn","name":"Latin","nativeName":"Latin","dir":"ltr","toScripts":[
+					{"code":"Jpan","name":"Japanese","nativeName":"日本語","dir":"ltr"}
+				]}
+			]},
+			"hi":{"name":"Hindi","nativeName":"हिन्दी","scripts":[
+				{"code":"Deva","name":"Devanagari","nativeName":"देवनागरी","dir":"ltr","toScripts":[
+					{"code":"Latn","name":"Latin","nativeName":"Latin","dir":"ltr"}
+				]}
+			]}
+		}}`)
+	}))
+	defer server.Close()
+
+	catalog := newScriptCatalogV3(_http.NewAuthenticatedClient(), newRouterV3(server.URL, ""), translator.DefaultCatalogTTL)
+
+	expected := []translator.Script{
+		{Code: "Jpan", Name: "Japanese", ToScripts: []string{"Latn"}},
+		{Code: "Latn", Name: "Latin", ToScripts: []string{"Jpan"}},
+	}
+
+	for i := 0; i < 2; i++ {
+		actual, err := catalog.Scripts(context.Background(), "ja")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unexpected scripts. Want: %v. Got: %v.", expected, actual)
+		}
+	}
+
+	_, err := catalog.Scripts(context.Background(), "de")
+	if !errors.Is(err, translator.ErrUnsupportedLanguage) {
+		t.Fatalf("Expected ErrUnsupportedLanguage. Got: %v", err)
+	}
+
+	if count := atomic.LoadInt32(&requestCounter); count != 1 {
+		t.Fatalf("Unexpected number of requests: %d. Expected: 1.", count)
+	}
+}
+
+func TestScriptCatalogV3Expired(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCounter, 1) > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"transliteration":{"ar":{"scripts":[{"code":"Arab","name":"Arabic","toScripts":[{"code":"Latn"}]}]}}}`)
+	}))
+	defer server.Close()
+
+	catalog := newScriptCatalogV3(_http.NewAuthenticatedClient(_http.WithRetryPolicy(_http.RetryPolicy{MaxAttempts: 1})), newRouterV3(server.URL, ""), time.Nanosecond)
+
+	for i := 0; i < 3; i++ {
+		scripts, err := catalog.Scripts(context.Background(), "ar")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if len(scripts) != 1 || scripts[0].Code != "Arab" {
+			t.Fatalf("Unexpected scripts: %v", scripts)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := catalog.Refresh(context.Background()); err == nil {
+		t.Fatal("Expected error from Refresh.")
+	}
+
+	if _, err := catalog.Scripts(context.Background(), "ar"); err != nil {
+		t.Fatalf("Unexpected error after failed refresh: %s", err.Error())
+	}
+
+	if count := atomic.LoadInt32(&requestCounter); count < 2 {
+		t.Fatalf("Unexpected number of requests: %d. Expected at least 2.", count)
+	}
+}
+
+func TestScriptCatalogV3Refresh(t *testing.T) {
+	var requestCounter int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := "Arab"
+		if atomic.AddInt32(&requestCounter, 1) > 1 {
+			code = "Latn"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"transliteration":{"ar":{"scripts":[{"code":"%s"}]}}}`, code)
+	}))
+	defer server.Close()
+
+	catalog := newScriptCatalogV3(_http.NewAuthenticatedClient(), newRouterV3(server.URL, ""), 0)
+
+	// scripts that have never been requested are not fetched
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if count := atomic.LoadInt32(&requestCounter); count != 0 {
+		t.Fatalf("Unexpected number of requests: %d. Expected: 0.", count)
+	}
+
+	if _, err := catalog.Scripts(context.Background(), "ar"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	scripts, err := catalog.Scripts(context.Background(), "ar")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if len(scripts) != 1 || scripts[0].Code != "Latn" {
+		t.Fatalf("Unexpected scripts after refresh: %v", scripts)
+	}
+
+	if count := atomic.LoadInt32(&requestCounter); count != 2 {
+		t.Fatalf("Unexpected number of requests: %d. Expected: 2.", count)
+	}
+}
@@ -1,20 +1,47 @@
 package microsoft
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"net/url"
+	"strings"
 
 	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
 	"github.com/st3v/translator/http"
 )
 
+// Microsoft accepts at most 2000 texts with a total of 10000 characters
+// per TranslateArray or DetectArray request.
+const (
+	maxBatchTexts = 2000
+	maxBatchChars = 10000
+)
+
+// Servers and proxies reject overly long URLs. Texts that would make the URL
+// of a Translate or Detect request longer than maxURLLength are sent in the
+// XML body of a TranslateArray or DetectArray request instead.
+const maxURLLength = 2000
+
+// Content types of the texts passed to version 2 of Microsoft's API.
+const (
+	contentTypeText = "text/plain"
+	contentTypeHTML = "text/html"
+)
+
+// defaultCategory is the category of Microsoft's standard translation model.
+const defaultCategory = "general"
+
 // The TranslationProvider communicates with Microsoft's
 // API to provide a translation for a given text.
 type TranslationProvider interface {
-	Translate(text, from, to string) (string, error)
-	Detect(text string) (string, error)
+	Translate(ctx context.Context, text, from, to string) (string, error)
+	TranslateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error)
+	TranslateArray(ctx context.Context, texts []string, from, to string) ([]string, error)
+	Detect(ctx context.Context, text string) (string, error)
+	DetectArray(ctx context.Context, texts []string) ([]string, error)
 }
 
 type translationProvider struct {
@@ -22,14 +49,33 @@ type translationProvider struct {
 	httpClient http.Client
 }
 
-func newTranslationProvider(authenticator http.Authenticator, router Router) TranslationProvider {
+func newTranslationProvider(httpClient http.Client, router Router) TranslationProvider {
 	return &translationProvider{
 		router:     router,
-		httpClient: http.NewClient(authenticator),
+		httpClient: httpClient,
 	}
 }
 
-func (p *translationProvider) Translate(text, from, to string) (string, error) {
+func (p *translationProvider) Translate(ctx context.Context, text, from, to string) (string, error) {
+	result, err := p.TranslateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+	return result.Text, err
+}
+
+// TranslateWithOptions translates a text according to the given options.
+// The API supports the Format and Category options. HTML is passed to the
+// API as text/html, which preserves tags and entities. The Translate method
+// of the API does not report the source language it detects, texts without
+// a source language are therefore sent to TranslateArray instead.
+func (p *translationProvider) TranslateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error) {
+	if err := opts.Validate(provider, "Format", "Category"); err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	contentType := contentTypeText
+	if opts.Format == translator.HTML {
+		contentType = contentTypeHTML
+	}
+
 	uri := fmt.Sprintf(
 		"%s?text=%s&from=%s&to=%s",
 		p.router.TranslationURL(),
@@ -37,35 +83,135 @@ func (p *translationProvider) Translate(text, from, to string) (string, error) {
 		url.QueryEscape(from),
 		url.QueryEscape(to))
 
-	response, err := p.httpClient.SendRequest("GET", uri, nil, "text/plain")
+	if contentType != contentTypeText {
+		uri += "&contentType=" + url.QueryEscape(contentType)
+	}
+
+	if opts.Category != "" {
+		uri += "&category=" + url.QueryEscape(opts.Category)
+	}
+
+	if from == "" || len(uri) > maxURLLength {
+		results, err := p.translateArray(ctx, []string{text}, from, to, contentType, opts.Category)
+		if err != nil {
+			return translator.TranslateResult{}, err
+		}
+		return results[0], nil
+	}
+
+	response, err := p.httpClient.SendRequest(http.ContextWithTexts(ctx, text), "GET", uri, nil, "text/plain")
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return translator.TranslateResult{}, http.WrapError(err)
+	}
+
+	if err := checkResponse(response); err != nil {
+		return translator.TranslateResult{}, err
 	}
 
 	body, err := ioutil.ReadAll(response.Body)
 	defer response.Body.Close()
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return translator.TranslateResult{}, tracerr.Wrap(err)
 	}
 
 	translation := &xmlString{}
 	err = xml.Unmarshal(body, &translation)
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return translator.TranslateResult{}, tracerr.Wrap(err)
+	}
+
+	return translator.TranslateResult{Text: translation.Value}, nil
+}
+
+func (p *translationProvider) TranslateArray(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	results, err := p.translateArray(ctx, texts, from, to, contentTypeText, "")
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make([]string, len(results))
+	for i, result := range results {
+		translations[i] = result.Text
 	}
 
-	return translation.Value, nil
+	return translations, nil
 }
 
-func (p *translationProvider) Detect(text string) (string, error) {
+// translateArray translates the given texts in as few requests as possible.
+// The results hold the detected source languages if from is empty.
+func (p *translationProvider) translateArray(ctx context.Context, texts []string, from, to, contentType, category string) ([]translator.TranslateResult, error) {
+	results := make([]translator.TranslateResult, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		payload, err := xml.Marshal(newXMLTranslateArrayRequest(batch, from, to, contentType, category))
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		response, err := p.httpClient.SendRequest(
+			http.ContextWithTexts(ctx, batch...),
+			"POST",
+			p.router.TranslateArrayURL(),
+			strings.NewReader(string(payload)),
+			"text/xml",
+		)
+
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		if err := checkResponse(response); err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		result := &xmlTranslateArrayResponse{}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		if len(result.Responses) != len(batch) {
+			return nil, tracerr.Errorf("Unexpected number of translations: %d. Expected: %d.", len(result.Responses), len(batch))
+		}
+
+		for _, r := range result.Responses {
+			translation := translator.TranslateResult{Text: r.TranslatedText}
+			if from == "" {
+				translation.DetectedLanguage = r.From
+			}
+			results = append(results, translation)
+		}
+	}
+
+	return results, nil
+}
+
+func (p *translationProvider) Detect(ctx context.Context, text string) (string, error) {
 	uri := fmt.Sprintf(
 		"%s?text=%s",
 		p.router.DetectURL(),
 		url.QueryEscape(text))
 
-	response, err := p.httpClient.SendRequest("GET", uri, nil, "text/plain")
+	if len(uri) > maxURLLength {
+		languages, err := p.DetectArray(ctx, []string{text})
+		if err != nil {
+			return "", err
+		}
+		return languages[0], nil
+	}
+
+	response, err := p.httpClient.SendRequest(http.ContextWithTexts(ctx, text), "GET", uri, nil, "text/plain")
 	if err != nil {
-		return "", tracerr.Wrap(err)
+		return "", http.WrapError(err)
+	}
+
+	if err := checkResponse(response); err != nil {
+		return "", err
 	}
 
 	body, err := ioutil.ReadAll(response.Body)
@@ -82,3 +228,49 @@ func (p *translationProvider) Detect(text string) (string, error) {
 
 	return detect.Value, nil
 }
+
+func (p *translationProvider) DetectArray(ctx context.Context, texts []string) ([]string, error) {
+	languages := make([]string, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTexts, maxBatchChars) {
+		payload, err := xml.Marshal(newXMLArrayOfStrings(batch))
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		response, err := p.httpClient.SendRequest(
+			http.ContextWithTexts(ctx, batch...),
+			"POST",
+			p.router.DetectArrayURL(),
+			strings.NewReader(string(payload)),
+			"text/xml",
+		)
+
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		if err := checkResponse(response); err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		result := &xmlArrayOfStrings{}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, tracerr.Wrap(err)
+		}
+
+		if len(result.Strings) != len(batch) {
+			return nil, tracerr.Errorf("Unexpected number of detections: %d. Expected: %d.", len(result.Strings), len(batch))
+		}
+
+		languages = append(languages, result.Strings...)
+	}
+
+	return languages, nil
+}
@@ -1,12 +1,17 @@
 package microsoft
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/st3v/translator"
 	_http "github.com/st3v/translator/http"
 )
 
@@ -57,7 +62,7 @@ func TestTranslationProviderTranslate(t *testing.T) {
 		httpClient: _http.NewAuthenticatedClient(),
 	}
 
-	actualTranslation, err := translationProvider.Translate(expectedOriginal, expectedFrom, expectedTo)
+	actualTranslation, err := translationProvider.Translate(context.Background(), expectedOriginal, expectedFrom, expectedTo)
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err.Error())
 	}
@@ -104,7 +109,7 @@ func TestTranslationProviderDetect(t *testing.T) {
 		httpClient: _http.NewAuthenticatedClient(),
 	}
 
-	actualLanguage, err := translationProvider.Detect(text)
+	actualLanguage, err := translationProvider.Detect(context.Background(), text)
 	if err != nil {
 		t.Fatalf("Unexpected error: %s", err.Error())
 	}
@@ -114,6 +119,431 @@ func TestTranslationProviderDetect(t *testing.T) {
 	}
 }
 
+func TestTranslationProviderTranslateLongText(t *testing.T) {
+	expectedOriginal := strings.Repeat("Ich verstehe nur Bahnhof. ", 100)
+	expectedTranslation := "I only understand train station."
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "text/xml" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		request := &xmlTranslateArrayRequest{}
+		if err := xml.Unmarshal(body, request); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+		}
+
+		if request.From != "de" || request.To != "en" {
+			t.Fatalf("Unexpected `From` or `To` element in request: %s, %s", request.From, request.To)
+		}
+
+		if len(request.Texts) != 1 || request.Texts[0].Value != expectedOriginal {
+			t.Fatalf("Unexpected texts in request: %v", request.Texts)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+
+		fmt.Fprintf(
+			w,
+			`<ArrayOfTranslateArrayResponse xmlns="http://schemas.datacontract.org/2004/07/Microsoft.MT.Web.Service.V2"><TranslateArrayResponse><From>de</From><TranslatedText>%s</TranslatedText></TranslateArrayResponse></ArrayOfTranslateArrayResponse>`,
+			expectedTranslation,
+		)
+		return
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.translateArrayURL = server.URL
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	actualTranslation, err := translationProvider.Translate(context.Background(), expectedOriginal, "de", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualTranslation != expectedTranslation {
+		t.Fatalf("Unexpected translation: %s. Expected: %s.", actualTranslation, expectedTranslation)
+	}
+}
+
+func TestTranslationProviderDetectLongText(t *testing.T) {
+	text := strings.Repeat("Ich verstehe nur Bahnhof. ", 100)
+	expectedLanguage := "de"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "text/xml" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		request := &xmlArrayOfStrings{}
+		if err := xml.Unmarshal(body, request); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+		}
+
+		if len(request.Strings) != 1 || request.Strings[0] != text {
+			t.Fatalf("Unexpected texts in request: %v", request.Strings)
+		}
+
+		response, err := xml.Marshal(newXMLArrayOfStrings([]string{expectedLanguage}))
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+
+		fmt.Fprint(w, string(response))
+		return
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.detectArrayURL = server.URL
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	actualLanguage, err := translationProvider.Detect(context.Background(), text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actualLanguage != expectedLanguage {
+		t.Fatalf("Unexpected language detected: %s. Expected: %s.", actualLanguage, expectedLanguage)
+	}
+}
+
+func TestTranslationProviderTranslateHTML(t *testing.T) {
+	translate := strings.NewReplacer("sind", "are", "Freunde", "friends").Replace
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var texts []string
+
+		switch r.URL.Path {
+		case "/translate":
+			if r.FormValue("contentType") != "text/html" {
+				t.Fatalf("Unexpected `contentType` param in request: %s", r.FormValue("contentType"))
+			}
+
+			response, err := xml.Marshal(newXMLString(translate(r.FormValue("text"))))
+			if err != nil {
+				t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+			}
+
+			w.Header().Set("Content-Type", "text/xml")
+			w.Write(response)
+			return
+		case "/translate_array":
+			request := &xmlTranslateArrayRequest{}
+			if err := xml.NewDecoder(r.Body).Decode(request); err != nil {
+				t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+			}
+
+			if request.Options == nil || request.Options.ContentType == nil || request.Options.ContentType.Value != "text/html" {
+				t.Fatalf("Unexpected `Options` element in request: %+v", request.Options)
+			}
+
+			if request.Options.ContentType.Namespace != serviceNamespace {
+				t.Fatalf("Unexpected namespace for content type in request: %s", request.Options.ContentType.Namespace)
+			}
+
+			for _, text := range request.Texts {
+				texts = append(texts, text.Value)
+			}
+		default:
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		response := &xmlTranslateArrayResponse{}
+		for _, text := range texts {
+			response.Responses = append(response.Responses, struct {
+				From           string `xml:"From"`
+				TranslatedText string `xml:"TranslatedText"`
+			}{"de", translate(text)})
+		}
+
+		payload, err := xml.Marshal(response)
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.translationURL = server.URL + "/translate"
+	router.translateArrayURL = server.URL + "/translate_array"
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	original := `<p class="intro">Tom &amp; Jerry <b>sind <i>Freunde</i></b> &lt;3<br/></p>`
+	expectedTranslation := `<p class="intro">Tom &amp; Jerry <b>are <i>friends</i></b> &lt;3<br/></p>`
+
+	for _, n := range []int{1, 50} {
+		result, err := translationProvider.TranslateWithOptions(
+			context.Background(),
+			strings.Repeat(original, n),
+			"de",
+			"en",
+			translator.TranslateOptions{Format: translator.HTML},
+		)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		if result.Text != strings.Repeat(expectedTranslation, n) {
+			t.Fatalf("Unexpected translation: %s. Expected: %s.", result.Text, strings.Repeat(expectedTranslation, n))
+		}
+	}
+}
+
+func TestTranslationProviderTranslateOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("category") != "tech" {
+			t.Fatalf("Unexpected `category` param in request: %s", r.FormValue("category"))
+		}
+
+		if _, ok := r.URL.Query()["contentType"]; ok {
+			t.Fatalf("Unexpected `contentType` param in request: %s", r.URL.RawQuery)
+		}
+
+		response, err := xml.Marshal(newXMLString("Hello"))
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.translationURL = server.URL
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	result, err := translationProvider.TranslateWithOptions(context.Background(), "Hallo", "de", "en", translator.TranslateOptions{Category: "tech"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if result.Text != "Hello" {
+		t.Fatalf("Unexpected translation: %s. Expected: Hello.", result.Text)
+	}
+
+	for _, opts := range []translator.TranslateOptions{
+		{Model: "nmt"},
+		{Profanity: translator.ProfanityDelete},
+		{Glossary: "my-glossary"},
+	} {
+		_, err := translationProvider.TranslateWithOptions(context.Background(), "Hallo", "de", "en", opts)
+
+		optionErr := &translator.OptionError{}
+		if !errors.As(err, &optionErr) || optionErr.Provider != "microsoft" {
+			t.Fatalf("Expected OptionError for %+v. Got: %v", opts, err)
+		}
+	}
+}
+
+func TestTranslationProviderTranslateArray(t *testing.T) {
+	expectedFrom := "de"
+	expectedTo := "en"
+
+	originals := make([]string, maxBatchTexts+1)
+	expectedTranslations := make([]string, len(originals))
+	for i := range originals {
+		originals[i] = fmt.Sprintf("Text %d", i)
+		expectedTranslations[i] = fmt.Sprintf("Translation %d", i)
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "text/xml" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		request := &xmlTranslateArrayRequest{}
+		if err := xml.Unmarshal(body, request); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+		}
+
+		if request.From != expectedFrom {
+			t.Fatalf("Unexpected `From` element in request: %s", request.From)
+		}
+
+		if request.To != expectedTo {
+			t.Fatalf("Unexpected `To` element in request: %s", request.To)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+
+		fmt.Fprint(w, `<ArrayOfTranslateArrayResponse xmlns="http://schemas.datacontract.org/2004/07/Microsoft.MT.Web.Service.V2">`)
+		for _, text := range request.Texts {
+			if text.Namespace != arraysNamespace {
+				t.Fatalf("Unexpected namespace for text in request: %s", text.Namespace)
+			}
+
+			fmt.Fprintf(
+				w,
+				"<TranslateArrayResponse><From>%s</From><TranslatedText>%s</TranslatedText></TranslateArrayResponse>",
+				expectedFrom,
+				strings.Replace(text.Value, "Text", "Translation", 1),
+			)
+		}
+		fmt.Fprint(w, "</ArrayOfTranslateArrayResponse>")
+		return
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.translateArrayURL = server.URL
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	actualTranslations, err := translationProvider.TranslateArray(context.Background(), originals, expectedFrom, expectedTo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+
+	if len(actualTranslations) != len(expectedTranslations) {
+		t.Fatalf("Unexpected number of translations: %d", len(actualTranslations))
+	}
+
+	for i := range expectedTranslations {
+		if actualTranslations[i] != expectedTranslations[i] {
+			t.Fatalf("Unexpected translation: %s. Expected: %s.", actualTranslations[i], expectedTranslations[i])
+		}
+	}
+}
+
+func TestTranslationProviderDetectArray(t *testing.T) {
+	texts := make([]string, maxBatchTexts+1)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("Text %d", i)
+	}
+
+	requestCounter := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.Method != "POST" {
+			t.Fatalf("Unexpected request method: %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "text/xml" {
+			t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error reading request body: %s", err.Error())
+		}
+
+		request := &xmlArrayOfStrings{}
+		if err := xml.Unmarshal(body, request); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+		}
+
+		// answer with the index of each text as its language
+		languages := make([]string, len(request.Strings))
+		for i, text := range request.Strings {
+			languages[i] = strings.TrimPrefix(text, "Text ")
+		}
+
+		response, err := xml.Marshal(newXMLArrayOfStrings(languages))
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, string(response))
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.detectArrayURL = server.URL
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	actualLanguages, err := translationProvider.DetectArray(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 http requests but counted %d.", requestCounter)
+	}
+
+	if len(actualLanguages) != len(texts) {
+		t.Fatalf("Unexpected number of languages: %d", len(actualLanguages))
+	}
+
+	for i, language := range actualLanguages {
+		if language != fmt.Sprint(i) {
+			t.Fatalf("Unexpected language: %s. Expected: %d.", language, i)
+		}
+	}
+}
+
 func newMockTranslationProvider(text, from, to, translation string, t *testing.T) *mockTranslationProvider {
 	return &mockTranslationProvider{
 		text:        text,
@@ -129,10 +559,11 @@ type mockTranslationProvider struct {
 	from        string
 	to          string
 	translation string
+	opts        translator.TranslateOptions
 	t           *testing.T
 }
 
-func (p *mockTranslationProvider) Translate(text, from, to string) (string, error) {
+func (p *mockTranslationProvider) Translate(ctx context.Context, text, from, to string) (string, error) {
 	if p.text != text {
 		p.t.Fatalf("Unexpected text value: `%s`", text)
 	}
@@ -147,6 +578,87 @@ func (p *mockTranslationProvider) Translate(text, from, to string) (string, erro
 	return p.translation, nil
 }
 
-func (p *mockTranslationProvider) Detect(text string) (string, error) {
+func (p *mockTranslationProvider) TranslateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error) {
+	p.opts = opts
+	translation, err := p.Translate(ctx, text, from, to)
+	return translator.TranslateResult{Text: translation}, err
+}
+
+func (p *mockTranslationProvider) TranslateArray(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	if p.from != from {
+		p.t.Fatalf("Unexpected from value: `%s`", from)
+	}
+
+	if p.to != to {
+		p.t.Fatalf("Unexpected to value: `%s`", to)
+	}
+
+	translations := make([]string, len(texts))
+	for i := range texts {
+		translations[i] = p.translation
+	}
+	return translations, nil
+}
+
+func (p *mockTranslationProvider) Detect(ctx context.Context, text string) (string, error) {
 	return p.from, nil
 }
+
+func (p *mockTranslationProvider) DetectArray(ctx context.Context, texts []string) ([]string, error) {
+	languages := make([]string, len(texts))
+	for i := range texts {
+		languages[i] = p.from
+	}
+	return languages, nil
+}
+
+func TestTranslationProviderTranslateDetectedLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate_array" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		request := &xmlTranslateArrayRequest{}
+		if err := xml.NewDecoder(r.Body).Decode(request); err != nil {
+			t.Fatalf("Unexpected error unmarshalling xml request body: %s", err.Error())
+		}
+
+		if request.From != "" {
+			t.Fatalf("Unexpected `From` element in request: %s", request.From)
+		}
+
+		response := &xmlTranslateArrayResponse{}
+		response.Responses = append(response.Responses, struct {
+			From           string `xml:"From"`
+			TranslatedText string `xml:"TranslatedText"`
+		}{"de", "Hello"})
+
+		payload, err := xml.Marshal(response)
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling xml repsonse: %s", err.Error())
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	router := newMockRouter()
+	router.translationURL = server.URL + "/translate"
+	router.translateArrayURL = server.URL + "/translate_array"
+
+	translationProvider := &translationProvider{
+		router:     router,
+		httpClient: _http.NewAuthenticatedClient(),
+	}
+
+	result, err := translationProvider.TranslateWithOptions(context.Background(), "Hallo", "", "en", translator.TranslateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expected := translator.TranslateResult{Text: "Hello", DetectedLanguage: "de"}
+	if result != expected {
+		t.Fatalf("Unexpected result: %+v. Expected: %+v.", result, expected)
+	}
+}
@@ -0,0 +1,416 @@
+package microsoft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/st3v/tracerr"
+	"github.com/st3v/translator"
+	"github.com/st3v/translator/http"
+)
+
+const apiVersionV3 = "3.0"
+
+// Version 3 of Microsoft's API accepts at most 1000 texts with a total of
+// 50000 characters per translate request and at most 100 texts per detect
+// request.
+const (
+	maxBatchTextsV3  = 1000
+	maxBatchCharsV3  = 50000
+	maxDetectTextsV3 = 100
+)
+
+// DictionaryTranslation is an alternative translation of a word or phrase
+// as returned by a dictionary lookup.
+type DictionaryTranslation struct {
+	// Target is the translation in the form best suited for display.
+	Target string
+
+	// PartOfSpeech is the part of speech of the translation, e.g. NOUN or VERB.
+	PartOfSpeech string
+
+	// Confidence indicates the likelihood of the translation between 0 and 1.
+	// The confidences of all translations of a term add up to 1.
+	Confidence float64
+
+	// BackTranslations are translations of Target back into the source
+	// language.
+	BackTranslations []string
+}
+
+type textV3 struct {
+	Text string
+}
+
+type translationResultV3 struct {
+	DetectedLanguage struct {
+		Language string
+		Score    float64
+	}
+	Translations []struct {
+		Text string
+		To   string
+	}
+}
+
+// translation returns the translation of a result of a request with a single
+// target language.
+func (r translationResultV3) translation() (string, error) {
+	if len(r.Translations) == 0 {
+		return "", tracerr.Error("Missing translation.")
+	}
+	return r.Translations[0].Text, nil
+}
+
+// translations returns the translations of the result keyed by the given
+// target languages, whose codes may differ in case from the ones reported
+// by the API.
+func (r translationResultV3) translations(to []string) (map[string]string, error) {
+	translations := make(map[string]string, len(to))
+
+	for _, lang := range to {
+		found := false
+		for _, t := range r.Translations {
+			if strings.EqualFold(t.To, lang) {
+				translations[lang] = t.Text
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, tracerr.Errorf("Missing translation into %s.", lang)
+		}
+	}
+
+	return translations, nil
+}
+
+type detectionResultV3 struct {
+	Language     string
+	Score        float64
+	Alternatives []struct {
+		Language string
+		Score    float64
+	}
+}
+
+// detection returns the detected language of the result, which is considered
+// reliable if the API offers no alternatives.
+func (r detectionResultV3) detection() translator.Detection {
+	return translator.Detection{
+		Language:   r.Language,
+		Confidence: r.Score,
+		Reliable:   r.Language != "" && len(r.Alternatives) == 0,
+	}
+}
+
+type transliterationResultV3 struct {
+	Text   string
+	Script string
+}
+
+type dictionaryLookupResultV3 struct {
+	Translations []struct {
+		DisplayTarget    string
+		PosTag           string
+		Confidence       float64
+		BackTranslations []struct {
+			DisplayText string
+		}
+	}
+}
+
+type translationProviderV3 struct {
+	router     RouterV3
+	httpClient http.Client
+}
+
+func newTranslationProviderV3(httpClient http.Client, router RouterV3) *translationProviderV3 {
+	return &translationProviderV3{
+		router:     router,
+		httpClient: httpClient,
+	}
+}
+
+func (p *translationProviderV3) Translate(ctx context.Context, text, from, to string) (string, error) {
+	result, err := p.TranslateWithOptions(ctx, text, from, to, translator.TranslateOptions{})
+	return result.Text, err
+}
+
+// TranslateWithOptions translates a text according to the given options.
+// The API supports the Format, Category, and Profanity options. HTML is
+// passed to the API with textType html, which preserves tags and entities.
+// The result holds the detected source language if from is empty.
+func (p *translationProviderV3) TranslateWithOptions(ctx context.Context, text, from, to string, opts translator.TranslateOptions) (translator.TranslateResult, error) {
+	if err := opts.Validate(provider, "Format", "Category", "Profanity"); err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	results, err := p.translate(ctx, []string{text}, from, []string{to}, opts)
+	if err != nil {
+		return translator.TranslateResult{}, http.WrapError(err)
+	}
+
+	translation, err := results[0].translation()
+	if err != nil {
+		return translator.TranslateResult{}, err
+	}
+
+	return translator.TranslateResult{
+		Text:             translation,
+		DetectedLanguage: results[0].DetectedLanguage.Language,
+	}, nil
+}
+
+func (p *translationProviderV3) TranslateArray(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	translations := make([]string, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxBatchTextsV3, maxBatchCharsV3) {
+		results, err := p.translate(ctx, batch, from, []string{to}, translator.TranslateOptions{})
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		for _, result := range results {
+			translation, err := result.translation()
+			if err != nil {
+				return nil, err
+			}
+			translations = append(translations, translation)
+		}
+	}
+
+	return translations, nil
+}
+
+// TranslateMulti translates the given text into all of the given languages
+// by means of a single request and returns the translations keyed by
+// language code.
+func (p *translationProviderV3) TranslateMulti(ctx context.Context, text, from string, to []string) (map[string]string, error) {
+	results, err := p.translate(ctx, []string{text}, from, to, translator.TranslateOptions{})
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	return results[0].translations(to)
+}
+
+func (p *translationProviderV3) Detect(ctx context.Context, text string) (string, error) {
+	detections, err := p.DetectDetailed(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	return detections[0].Language, nil
+}
+
+// DetectDetailed returns the detected language of the given text followed
+// by the alternatives the API considered. The API does not indicate whether
+// a detection is reliable, the detected language is considered reliable if
+// the API offers no alternatives.
+func (p *translationProviderV3) DetectDetailed(ctx context.Context, text string) ([]translator.Detection, error) {
+	results := []detectionResultV3{}
+
+	err := p.send(ctx, p.router.DetectURL(), url.Values{}, []string{text}, &results)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	if len(results) != 1 {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	if results[0].Language == "" {
+		return nil, translator.ErrNoDetection
+	}
+
+	detections := []translator.Detection{results[0].detection()}
+
+	for _, alternative := range results[0].Alternatives {
+		detections = append(detections, translator.Detection{
+			Language:   alternative.Language,
+			Confidence: alternative.Score,
+		})
+	}
+
+	return detections, nil
+}
+
+// DetectArray returns the detected language of each of the given texts.
+func (p *translationProviderV3) DetectArray(ctx context.Context, texts []string) ([]string, error) {
+	detections, err := p.DetectBatch(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]string, len(detections))
+	for i, d := range detections {
+		languages[i] = d.Language
+	}
+
+	return languages, nil
+}
+
+// DetectBatch returns the scored detection of each of the given texts.
+func (p *translationProviderV3) DetectBatch(ctx context.Context, texts []string) ([]translator.Detection, error) {
+	detections := make([]translator.Detection, 0, len(texts))
+
+	for _, batch := range http.Batch(texts, maxDetectTextsV3, maxBatchCharsV3) {
+		results := []detectionResultV3{}
+
+		err := p.send(ctx, p.router.DetectArrayURL(), url.Values{}, batch, &results)
+		if err != nil {
+			return nil, http.WrapError(err)
+		}
+
+		if len(results) != len(batch) {
+			return nil, tracerr.Errorf("Unexpected number of detections: %d. Expected: %d.", len(results), len(batch))
+		}
+
+		for _, result := range results {
+			detections = append(detections, result.detection())
+		}
+	}
+
+	return detections, nil
+}
+
+// Transliterate converts the given text in the given language from one
+// script to another, e.g. from Jpan to Latn.
+func (p *translationProviderV3) Transliterate(ctx context.Context, text, language, fromScript, toScript string) (string, error) {
+	params := url.Values{}
+	params.Set("language", language)
+	params.Set("fromScript", fromScript)
+	params.Set("toScript", toScript)
+
+	results := []transliterationResultV3{}
+
+	err := p.send(ctx, p.router.TransliterationURL(), params, []string{text}, &results)
+	if err != nil {
+		return "", http.WrapError(err)
+	}
+
+	if len(results) != 1 {
+		return "", tracerr.Error("Invalid response.")
+	}
+
+	return results[0].Text, nil
+}
+
+// LookupDictionary returns alternative translations of the given word or
+// short phrase.
+func (p *translationProviderV3) LookupDictionary(ctx context.Context, text, from, to string) ([]DictionaryTranslation, error) {
+	params := url.Values{}
+	params.Set("from", from)
+	params.Set("to", to)
+
+	results := []dictionaryLookupResultV3{}
+
+	err := p.send(ctx, p.router.DictionaryLookupURL(), params, []string{text}, &results)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	if len(results) != 1 {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	translations := make([]DictionaryTranslation, len(results[0].Translations))
+	for i, t := range results[0].Translations {
+		translations[i] = DictionaryTranslation{
+			Target:       t.DisplayTarget,
+			PartOfSpeech: t.PosTag,
+			Confidence:   t.Confidence,
+		}
+
+		for _, bt := range t.BackTranslations {
+			translations[i].BackTranslations = append(translations[i].BackTranslations, bt.DisplayText)
+		}
+	}
+
+	return translations, nil
+}
+
+// translate returns one result per text. The source language is detected if
+// from is empty.
+func (p *translationProviderV3) translate(ctx context.Context, texts []string, from string, to []string, opts translator.TranslateOptions) ([]translationResultV3, error) {
+	params := url.Values{}
+	if from != "" {
+		params.Set("from", from)
+	}
+
+	if opts.Format == translator.HTML {
+		params.Set("textType", "html")
+	}
+
+	if opts.Category != "" {
+		params.Set("category", opts.Category)
+	}
+
+	switch opts.Profanity {
+	case translator.ProfanityMark:
+		params.Set("profanityAction", "Marked")
+	case translator.ProfanityDelete:
+		params.Set("profanityAction", "Deleted")
+	}
+
+	for _, lang := range to {
+		params.Add("to", lang)
+	}
+
+	results := []translationResultV3{}
+
+	err := p.send(ctx, p.router.TranslationURL(), params, texts, &results)
+	if err != nil {
+		return nil, http.WrapError(err)
+	}
+
+	if len(results) != len(texts) {
+		return nil, tracerr.Error("Invalid response.")
+	}
+
+	return results, nil
+}
+
+// send posts the given texts to the given endpoint and decodes the JSON
+// response into target.
+func (p *translationProviderV3) send(ctx context.Context, endpoint string, params url.Values, texts []string, target interface{}) error {
+	params.Set("api-version", apiVersionV3)
+
+	items := make([]textV3, len(texts))
+	for i, text := range texts {
+		items[i] = textV3{Text: text}
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	response, err := p.httpClient.SendRequest(
+		http.ContextWithTexts(ctx, texts...),
+		"POST",
+		endpoint+"?"+params.Encode(),
+		bytes.NewReader(body),
+		"application/json",
+	)
+	if err != nil {
+		return http.WrapError(err)
+	}
+
+	if err := checkResponseV3(response); err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return tracerr.Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,511 @@
+package microsoft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/st3v/translator"
+	_http "github.com/st3v/translator/http"
+)
+
+func newTestTranslationProviderV3(handler http.HandlerFunc) (*translationProviderV3, func()) {
+	server := httptest.NewServer(handler)
+	provider := newTranslationProviderV3(_http.NewAuthenticatedClient(), newRouterV3(server.URL, ""))
+	return provider, server.Close
+}
+
+func decodeTextsV3(t *testing.T, r *http.Request) []string {
+	if r.Method != "POST" {
+		t.Fatalf("Unexpected request method: %s", r.Method)
+	}
+
+	if r.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Unexpected content type in request header: %s", r.Header.Get("Content-Type"))
+	}
+
+	if r.URL.Query().Get("api-version") != "3.0" {
+		t.Fatalf("Unexpected `api-version` param in request: %s", r.URL.Query().Get("api-version"))
+	}
+
+	items := []textV3{}
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		t.Fatalf("Unexpected error decoding request: %s", err.Error())
+	}
+
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = item.Text
+	}
+
+	return texts
+}
+
+func TestTranslationProviderV3Translate(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		texts := decodeTextsV3(t, r)
+		if !reflect.DeepEqual(texts, []string{"Ich verstehe nur Bahnhof."}) {
+			t.Fatalf("Unexpected texts in request: %v", texts)
+		}
+
+		if r.URL.Query().Get("from") != "de" {
+			t.Fatalf("Unexpected `from` param in request: %s", r.URL.Query().Get("from"))
+		}
+
+		if r.URL.Query().Get("to") != "en" {
+			t.Fatalf("Unexpected `to` param in request: %s", r.URL.Query().Get("to"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"I only understand train station.","to":"en"}]}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.Translate(context.Background(), "Ich verstehe nur Bahnhof.", "de", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := "I only understand train station."; actual != want {
+		t.Fatalf("Unexpected translation. Want: %q. Got: %q.", want, actual)
+	}
+}
+
+func TestTranslationProviderV3TranslateHTML(t *testing.T) {
+	original := `<p class="intro">Tom &amp; Jerry <b>sind <i>Freunde</i></b> &lt;3<br/></p>`
+	expected := `<p class="intro">Tom &amp; Jerry <b>are <i>friends</i></b> &lt;3<br/></p>`
+
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("textType") != "html" {
+			t.Fatalf("Unexpected `textType` param in request: %s", r.URL.Query().Get("textType"))
+		}
+
+		texts := decodeTextsV3(t, r)
+		if !reflect.DeepEqual(texts, []string{original}) {
+			t.Fatalf("Unexpected texts in request: %v", texts)
+		}
+
+		result := []translationResultV3{{}}
+		result[0].Translations = append(result[0].Translations, struct {
+			Text string
+			To   string
+		}{expected, "en"})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+	defer closeServer()
+
+	actual, err := provider.TranslateWithOptions(context.Background(), original, "de", "en", translator.TranslateOptions{Format: translator.HTML})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actual.Text != expected {
+		t.Fatalf("Unexpected translation. Want: %q. Got: %q.", expected, actual.Text)
+	}
+}
+
+func TestTranslationProviderV3TranslateOptions(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("category") != "tech" {
+			t.Fatalf("Unexpected `category` param in request: %s", r.URL.Query().Get("category"))
+		}
+
+		if r.URL.Query().Get("profanityAction") != "Marked" {
+			t.Fatalf("Unexpected `profanityAction` param in request: %s", r.URL.Query().Get("profanityAction"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hello <profanity>***</profanity>","to":"en"}]}]`)
+	})
+	defer closeServer()
+
+	opts := translator.TranslateOptions{
+		Category:  "tech",
+		Profanity: translator.ProfanityMark,
+	}
+
+	actual, err := provider.TranslateWithOptions(context.Background(), "Hallo ***", "de", "en", opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := "Hello <profanity>***</profanity>"; actual.Text != want {
+		t.Fatalf("Unexpected translation. Want: %q. Got: %q.", want, actual.Text)
+	}
+
+	_, err = provider.TranslateWithOptions(context.Background(), "Hallo", "de", "en", translator.TranslateOptions{Glossary: "my-glossary"})
+	if !errors.Is(err, translator.ErrUnsupportedOption) {
+		t.Fatalf("Expected ErrUnsupportedOption. Got: %v", err)
+	}
+}
+
+func TestTranslationProviderV3TranslateWithoutSource(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["from"]; ok {
+			t.Fatalf("Unexpected `from` param in request: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"detectedLanguage":{"language":"de","score":1.0},"translations":[{"text":"Hello","to":"en"}]}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.Translate(context.Background(), "Hallo", "", "en")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := "Hello"; actual != want {
+		t.Fatalf("Unexpected translation. Want: %q. Got: %q.", want, actual)
+	}
+}
+
+func TestTranslationProviderV3TranslateArray(t *testing.T) {
+	texts := []string{"one", "two", "three"}
+
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if actual := decodeTextsV3(t, r); !reflect.DeepEqual(actual, texts) {
+			t.Fatalf("Unexpected texts in request: %v", actual)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"translations":[{"text":"eins","to":"de"}]},
+			{"translations":[{"text":"zwei","to":"de"}]},
+			{"translations":[{"text":"drei","to":"de"}]}
+		]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.TranslateArray(context.Background(), texts, "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := []string{"eins", "zwei", "drei"}; !reflect.DeepEqual(actual, want) {
+		t.Fatalf("Unexpected translations. Want: %v. Got: %v.", want, actual)
+	}
+}
+
+func TestTranslationProviderV3TranslateMulti(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if to := r.URL.Query()["to"]; !reflect.DeepEqual(to, []string{"de", "fr"}) {
+			t.Fatalf("Unexpected `to` params in request: %v", to)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hallo","to":"de"},{"text":"Bonjour","to":"fr"}]}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.TranslateMulti(context.Background(), "Hello", "en", []string{"de", "fr"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := map[string]string{"de": "Hallo", "fr": "Bonjour"}; !reflect.DeepEqual(actual, want) {
+		t.Fatalf("Unexpected translations. Want: %v. Got: %v.", want, actual)
+	}
+}
+
+func TestTranslationProviderV3TranslateTargetCase(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hallo","to":"de"}]}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.Translate(context.Background(), "Hello", "en", "DE")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actual != "Hallo" {
+		t.Fatalf("Unexpected translation. Want: Hallo. Got: %q.", actual)
+	}
+
+	translations, err := provider.TranslateMulti(context.Background(), "Hello", "en", []string{"DE"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if want := map[string]string{"DE": "Hallo"}; !reflect.DeepEqual(translations, want) {
+		t.Fatalf("Unexpected translations. Want: %v. Got: %v.", want, translations)
+	}
+}
+
+func TestTranslationProviderV3TranslateMissingTarget(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[{"text":"Hallo","to":"de"}]}]`)
+	})
+	defer closeServer()
+
+	if _, err := provider.TranslateMulti(context.Background(), "Hello", "en", []string{"de", "fr"}); err == nil {
+		t.Fatal("Expected error for missing translation into fr.")
+	}
+}
+
+func TestTranslationProviderV3TranslateNoTranslation(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"translations":[]}]`)
+	})
+	defer closeServer()
+
+	if _, err := provider.Translate(context.Background(), "Hello", "en", "de"); err == nil {
+		t.Fatal("Expected error for missing translation.")
+	}
+}
+
+func TestTranslationProviderV3Detect(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/detect" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if texts := decodeTextsV3(t, r); !reflect.DeepEqual(texts, []string{"Hallo Welt!"}) {
+			t.Fatalf("Unexpected texts in request: %v", texts)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"language":"de","score":0.92,"isTranslationSupported":true,"isTransliterationSupported":false}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.Detect(context.Background(), "Hallo Welt!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actual != "de" {
+		t.Fatalf("Unexpected language. Want: %q. Got: %q.", "de", actual)
+	}
+}
+
+func TestTranslationProviderV3DetectDetailed(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"language":"de","score":0.62,"isTranslationSupported":true,"alternatives":[
+			{"language":"nl","score":0.31,"isTranslationSupported":true},
+			{"language":"af","score":0.07,"isTranslationSupported":true}
+		]}]`)
+	})
+	defer closeServer()
+
+	expected := []translator.Detection{
+		{Language: "de", Confidence: 0.62, Reliable: false},
+		{Language: "nl", Confidence: 0.31, Reliable: false},
+		{Language: "af", Confidence: 0.07, Reliable: false},
+	}
+
+	actual, err := provider.DetectDetailed(context.Background(), "Hallo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Unexpected detections. Want: %v. Got: %v.", expected, actual)
+	}
+}
+
+func TestTranslationProviderV3DetectNoDetection(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"language":"","score":0.0}]`)
+	})
+	defer closeServer()
+
+	_, err := provider.Detect(context.Background(), "?!")
+	if !errors.Is(err, translator.ErrNoDetection) {
+		t.Fatalf("Unexpected error. Want: %v. Got: %v.", translator.ErrNoDetection, err)
+	}
+}
+
+func TestTranslationProviderV3DetectBatch(t *testing.T) {
+	texts := make([]string, maxDetectTextsV3+1)
+	for i := range texts {
+		texts[i] = fmt.Sprint(i)
+	}
+
+	requestCounter := 0
+
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		requestCounter++
+
+		if r.URL.Path != "/detect" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		// answer with the text as the language, leave the first one undetected
+		results := []map[string]interface{}{}
+		for _, text := range decodeTextsV3(t, r) {
+			language := text
+			if text == "0" {
+				language = ""
+			}
+			results = append(results, map[string]interface{}{"language": language, "score": 0.5})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	})
+	defer closeServer()
+
+	actual, err := provider.DetectBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if requestCounter != 2 {
+		t.Fatalf("Expected 2 requests but counted %d.", requestCounter)
+	}
+
+	if len(actual) != len(texts) {
+		t.Fatalf("Unexpected number of detections: %d", len(actual))
+	}
+
+	for i, detection := range actual {
+		want := translator.Detection{Language: texts[i], Confidence: 0.5, Reliable: true}
+		if i == 0 {
+			want = translator.Detection{Confidence: 0.5}
+		}
+
+		if detection != want {
+			t.Fatalf("Unexpected detection. Want: %v. Got: %v.", want, detection)
+		}
+	}
+}
+
+func TestTranslationProviderV3Transliterate(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transliterate" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("language") != "ja" || query.Get("fromScript") != "Jpan" || query.Get("toScript") != "Latn" {
+			t.Fatalf("Unexpected params in request: %s", r.URL.RawQuery)
+		}
+
+		if texts := decodeTextsV3(t, r); !reflect.DeepEqual(texts, []string{"こんにちは"}) {
+			t.Fatalf("Unexpected texts in request: %v", texts)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"text":"konnichiwa","script":"Latn"}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.Transliterate(context.Background(), "こんにちは", "ja", "Jpan", "Latn")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if actual != "konnichiwa" {
+		t.Fatalf("Unexpected transliteration. Want: %q. Got: %q.", "konnichiwa", actual)
+	}
+}
+
+func TestTranslationProviderV3LookupDictionary(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dictionary/lookup" {
+			t.Fatalf("Unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("from") != "en" || r.URL.Query().Get("to") != "es" {
+			t.Fatalf("Unexpected params in request: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{
+			"normalizedSource": "fly",
+			"displaySource": "fly",
+			"translations": [
+				{
+					"normalizedTarget": "volar",
+					"displayTarget": "volar",
+					"posTag": "VERB",
+					"confidence": 0.4081,
+					"prefixWord": "",
+					"backTranslations": [
+						{"normalizedText": "fly", "displayText": "fly", "numExamples": 15, "frequencyCount": 4637},
+						{"normalizedText": "flying", "displayText": "flying", "numExamples": 15, "frequencyCount": 1365}
+					]
+				},
+				{
+					"normalizedTarget": "mosca",
+					"displayTarget": "mosca",
+					"posTag": "NOUN",
+					"confidence": 0.2668,
+					"prefixWord": "",
+					"backTranslations": [
+						{"normalizedText": "fly", "displayText": "fly", "numExamples": 15, "frequencyCount": 1697}
+					]
+				}
+			]
+		}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.LookupDictionary(context.Background(), "fly", "en", "es")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	want := []DictionaryTranslation{
+		{Target: "volar", PartOfSpeech: "VERB", Confidence: 0.4081, BackTranslations: []string{"fly", "flying"}},
+		{Target: "mosca", PartOfSpeech: "NOUN", Confidence: 0.2668, BackTranslations: []string{"fly"}},
+	}
+
+	if !reflect.DeepEqual(actual, want) {
+		t.Fatalf("Unexpected dictionary translations. Want: %+v. Got: %+v.", want, actual)
+	}
+}
+
+func TestTranslationProviderV3Error(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":400036,"message":"The target language is not valid."}}`)
+	})
+	defer closeServer()
+
+	_, err := provider.Translate(context.Background(), "Hello", "en", "xx")
+	if !errors.Is(err, translator.ErrUnsupportedLanguage) {
+		t.Fatalf("Unexpected error. Want: %v. Got: %v.", translator.ErrUnsupportedLanguage, err)
+	}
+}
+
+func TestTranslationProviderV3TranslateDetectedLanguage(t *testing.T) {
+	provider, closeServer := newTestTranslationProviderV3(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["from"]; ok {
+			t.Fatalf("Unexpected `from` param in request: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"detectedLanguage":{"language":"de","score":1.0},"translations":[{"text":"Hello","to":"en"}]}]`)
+	})
+	defer closeServer()
+
+	actual, err := provider.TranslateWithOptions(context.Background(), "Hallo", "", "en", translator.TranslateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expected := translator.TranslateResult{Text: "Hello", DetectedLanguage: "de"}
+	if actual != expected {
+		t.Fatalf("Unexpected result. Want: %+v. Got: %+v.", expected, actual)
+	}
+}
@@ -2,15 +2,22 @@ package microsoft
 
 import "encoding/xml"
 
+const (
+	serializationNamespace = "http://schemas.microsoft.com/2003/10/Serialization/"
+	arraysNamespace        = serializationNamespace + "Arrays"
+	instanceNamespace      = "http://www.w3.org/2001/XMLSchema-instance"
+	serviceNamespace       = "http://schemas.datacontract.org/2004/07/Microsoft.MT.Web.Service.V2"
+)
+
 type xmlString struct {
 	XMLName   xml.Name `xml:"string"`
 	Namespace string   `xml:"xmlns,attr"`
-	Value     string   `xml:",innerxml"`
+	Value     string   `xml:",chardata"`
 }
 
 func newXMLString(value string) *xmlString {
 	return &xmlString{
-		Namespace: "http://schemas.microsoft.com/2003/10/Serialization/",
+		Namespace: serializationNamespace,
 		Value:     value,
 	}
 }
@@ -24,8 +31,90 @@ type xmlArrayOfStrings struct {
 
 func newXMLArrayOfStrings(values []string) *xmlArrayOfStrings {
 	return &xmlArrayOfStrings{
-		Namespace:         "http://schemas.microsoft.com/2003/10/Serialization/Arrays",
-		InstanceNamespace: "http://www.w3.org/2001/XMLSchema-instance",
+		Namespace:         arraysNamespace,
+		InstanceNamespace: instanceNamespace,
 		Strings:           values,
 	}
 }
+
+// xmlArrayString is a single element of an xmlArrayOfStrings that is
+// embedded into another request, i.e. it has to carry its own namespace.
+type xmlArrayString struct {
+	Namespace string `xml:"xmlns,attr"`
+	Value     string `xml:",chardata"`
+}
+
+func newXMLArrayStrings(values []string) []xmlArrayString {
+	items := make([]xmlArrayString, len(values))
+	for i, v := range values {
+		items[i] = xmlArrayString{
+			Namespace: arraysNamespace,
+			Value:     v,
+		}
+	}
+	return items
+}
+
+// xmlServiceString is an option of a TranslateArray request, i.e. it has to
+// carry the namespace of the service.
+type xmlServiceString struct {
+	Namespace string `xml:"xmlns,attr"`
+	Value     string `xml:",chardata"`
+}
+
+func newXMLServiceString(value string) *xmlServiceString {
+	if value == "" {
+		return nil
+	}
+
+	return &xmlServiceString{
+		Namespace: serviceNamespace,
+		Value:     value,
+	}
+}
+
+type xmlTranslateOptions struct {
+	Category    *xmlServiceString `xml:"Category,omitempty"`
+	ContentType *xmlServiceString `xml:"ContentType,omitempty"`
+}
+
+type xmlTranslateArrayRequest struct {
+	XMLName xml.Name             `xml:"TranslateArrayRequest"`
+	AppID   string               `xml:"AppId"`
+	From    string               `xml:"From"`
+	Options *xmlTranslateOptions `xml:"Options,omitempty"`
+	Texts   []xmlArrayString     `xml:"Texts>string"`
+	To      string               `xml:"To"`
+}
+
+// newXMLTranslateArrayRequest returns a request for the translation of the
+// given texts. Options are omitted unless a category or a content type other
+// than text/plain is given.
+func newXMLTranslateArrayRequest(texts []string, from, to, contentType, category string) *xmlTranslateArrayRequest {
+	request := &xmlTranslateArrayRequest{
+		From:  from,
+		Texts: newXMLArrayStrings(texts),
+		To:    to,
+	}
+
+	if contentType == contentTypeText {
+		contentType = ""
+	}
+
+	if contentType != "" || category != "" {
+		request.Options = &xmlTranslateOptions{
+			Category:    newXMLServiceString(category),
+			ContentType: newXMLServiceString(contentType),
+		}
+	}
+
+	return request
+}
+
+type xmlTranslateArrayResponse struct {
+	XMLName   xml.Name `xml:"ArrayOfTranslateArrayResponse"`
+	Responses []struct {
+		From           string `xml:"From"`
+		TranslatedText string `xml:"TranslatedText"`
+	} `xml:"TranslateArrayResponse"`
+}
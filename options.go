@@ -0,0 +1,154 @@
+package translator
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format specifies the format of a text to translate.
+type Format int
+
+const (
+	// Text is plain text. It is the default format.
+	Text Format = iota
+
+	// HTML is an HTML document or fragment. Tags, attributes, and entities
+	// are preserved, only the text content is translated.
+	HTML
+)
+
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case HTML:
+		return "html"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Profanity specifies how profanities in translations are handled.
+type Profanity int
+
+const (
+	// ProfanityKeep leaves profanities as they are. It is the default.
+	ProfanityKeep Profanity = iota
+
+	// ProfanityMark masks or tags profanities, depending on the API.
+	ProfanityMark
+
+	// ProfanityDelete removes profanities from translations.
+	ProfanityDelete
+)
+
+func (p Profanity) String() string {
+	switch p {
+	case ProfanityKeep:
+		return "keep"
+	case ProfanityMark:
+		return "mark"
+	case ProfanityDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("Profanity(%d)", int(p))
+}
+
+// TranslateOptions modify how a text is translated. The zero value of each
+// option is the default of the API and is supported by all translators.
+type TranslateOptions struct {
+	// Format is the format of the text, plain text by default.
+	Format Format
+
+	// Model selects the translation model, e.g. nmt or base for Google's
+	// Translate API, or the name of a custom model for Cloud Translation
+	// Advanced.
+	Model string
+
+	// Category selects a custom translator model of Microsoft's API.
+	Category string
+
+	// Profanity specifies how profanities in translations are handled.
+	Profanity Profanity
+
+	// Glossary is the name or ID of a glossary, or terminology, to apply
+	// to the translation.
+	Glossary string
+}
+
+// TranslateRequest describes a text to translate from one language into
+// another, together with the options that modify its translation.
+type TranslateRequest struct {
+	// Text is the text to translate.
+	Text string
+
+	// From and To are the language codes or canonical BCP 47 tags of the
+	// source and target language.
+	From string
+	To   string
+
+	TranslateOptions
+}
+
+// TranslateResult is the outcome of a TranslateRequest. Fields other than
+// Text are left empty by translators that do not report them.
+type TranslateResult struct {
+	// Text is the translated text.
+	Text string
+
+	// DetectedLanguage is the language code of the source language as
+	// detected by the API. It is only set if no source language was given.
+	DetectedLanguage string
+
+	// Provider is the name of the API that translated the text, e.g. google.
+	Provider string
+
+	// Model is the translation model that translated the text, e.g. nmt or
+	// the category of a custom Microsoft model.
+	Model string
+
+	// BilledCharacters is the number of characters the API bills for the
+	// translation.
+	BilledCharacters int
+
+	// Latency is the time it took to obtain the translation, including
+	// retries.
+	Latency time.Duration
+}
+
+// Validate returns an *OptionError for the first option that is set to a
+// value other than its default and whose name, e.g. Category, is not among
+// the given supported options of the given provider.
+func (o TranslateOptions) Validate(provider string, supported ...string) error {
+	options := []struct {
+		name  string
+		value string
+		set   bool
+	}{
+		{"Format", o.Format.String(), o.Format != Text},
+		{"Model", o.Model, o.Model != ""},
+		{"Category", o.Category, o.Category != ""},
+		{"Profanity", o.Profanity.String(), o.Profanity != ProfanityKeep},
+		{"Glossary", o.Glossary, o.Glossary != ""},
+	}
+
+	for _, option := range options {
+		if option.set && !contains(supported, option.name) {
+			return &OptionError{
+				Provider: provider,
+				Option:   option.name,
+				Value:    option.value,
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
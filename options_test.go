@@ -0,0 +1,37 @@
+package translator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTranslateOptionsValidate(t *testing.T) {
+	if err := (TranslateOptions{}).Validate("fake-provider"); err != nil {
+		t.Fatalf("Default options should always be supported: %s", err.Error())
+	}
+
+	opts := TranslateOptions{Format: HTML, Profanity: ProfanityDelete}
+
+	if err := opts.Validate("fake-provider", "Format", "Profanity"); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	err := opts.Validate("fake-provider", "Format", "Model")
+	if !errors.Is(err, ErrUnsupportedOption) {
+		t.Fatalf("Expected error to be ErrUnsupportedOption: %v", err)
+	}
+
+	var optionErr *OptionError
+	if !errors.As(err, &optionErr) {
+		t.Fatalf("Expected error to be an OptionError: %s", err.Error())
+	}
+
+	want := OptionError{Provider: "fake-provider", Option: "Profanity", Value: "delete"}
+	if *optionErr != want {
+		t.Fatalf("Unexpected option error. Got: %+v. Want: %+v.", *optionErr, want)
+	}
+
+	if err.Error() != "fake-provider API does not support option Profanity=delete" {
+		t.Fatalf("Unexpected error message: %s", err.Error())
+	}
+}
@@ -0,0 +1,302 @@
+// Package segment splits long documents into segments that fit into a single
+// translation request and provides a Translator that translates documents of
+// any length through another Translator, one segment at a time.
+package segment
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Split splits the given text into segments of at most maxChars characters.
+// Segments end at paragraph boundaries, i.e. blank lines, and consecutive
+// sentences of a paragraph are combined as long as they fit. Sentences longer
+// than maxChars are split at the last whitespace that fits, or in the worst
+// case within a word. Text is never split inside an HTML or XML tag if it can
+// be avoided, and such sentences are rather cut in front of an element than
+// within it. Elements that do not fit into a single segment, e.g. paragraphs
+// that wrap several sentences or overly long elements, are split nonetheless,
+// in which case the markup of a segment is not balanced. Each segment keeps
+// the whitespace that follows it, so joining the segments yields the original
+// text. Sentences are not limited in length if maxChars is not positive.
+func Split(text string, maxChars int) []string {
+	var segments []string
+
+	for _, paragraph := range paragraphs(text) {
+		var current string
+
+		for _, sentence := range sentences(paragraph) {
+			if maxChars > 0 && length(current)+length(sentence) > maxChars && current != "" {
+				segments = append(segments, current)
+				current = ""
+			}
+
+			if maxChars > 0 && length(sentence) > maxChars {
+				parts := cut(sentence, maxChars)
+				segments = append(segments, parts[:len(parts)-1]...)
+				sentence = parts[len(parts)-1]
+			}
+
+			current += sentence
+		}
+
+		if current != "" {
+			segments = append(segments, current)
+		}
+	}
+
+	return segments
+}
+
+// paragraphs splits text after each run of whitespace that holds at least
+// two line breaks.
+func paragraphs(text string) []string {
+	var (
+		result []string
+		start  int
+	)
+
+	scan(text, func(end int, space string) {
+		if countNewlines(space) >= 2 && end < len(text) {
+			result = append(result, text[start:end])
+			start = end
+		}
+	}, nil)
+
+	return append(result, text[start:])
+}
+
+// sentences splits text after each sentence, i.e. after a terminating
+// punctuation mark followed by whitespace and anything but a lower case
+// letter. Terminators used in Chinese and Japanese do not require whitespace.
+func sentences(text string) []string {
+	var (
+		result     []string
+		start      int
+		terminated bool
+		fullWidth  bool
+	)
+
+	split := func(end int) {
+		if end > start && end < len(text) {
+			result = append(result, text[start:end])
+			start = end
+		}
+		terminated, fullWidth = false, false
+	}
+
+	scan(text, func(end int, space string) {
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if terminated && !unicode.IsLower(next) {
+			split(end)
+		}
+		terminated, fullWidth = false, false
+	}, func(offset int, r rune) {
+		switch {
+		case isTerminator(r):
+			terminated, fullWidth = true, isFullWidthTerminator(r)
+		case isCloser(r):
+			// closing quotes and brackets belong to the sentence they end
+		case terminated && fullWidth:
+			split(offset)
+		default:
+			terminated, fullWidth = false, false
+		}
+	})
+
+	return append(result, text[start:])
+}
+
+// scan walks through text, skipping HTML and XML tags. It calls onSpace with
+// each run of whitespace and the offset of the first rune that follows the
+// run, and onRune, if not nil, with any other rune and its offset.
+func scan(text string, onSpace func(end int, space string), onRune func(offset int, r rune)) {
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		if r == '<' && isTagStart(text[i+size:]) {
+			if end := tagEnd(text, i); end > 0 {
+				i = end
+				continue
+			}
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+			end := i
+			for end < len(text) {
+				r, size := utf8.DecodeRuneInString(text[end:])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				end += size
+			}
+			onSpace(end, text[i:end])
+			i = end
+		default:
+			if onRune != nil {
+				onRune(i, r)
+			}
+			i += size
+		}
+	}
+}
+
+// cut splits a text that is longer than maxChars characters into parts of at
+// most maxChars characters.
+func cut(text string, maxChars int) []string {
+	var parts []string
+
+	for length(text) > maxChars {
+		offset := cutOffset(text, maxChars)
+		parts = append(parts, text[:offset])
+		text = text[offset:]
+	}
+
+	return append(parts, text)
+}
+
+// cutOffset returns the offset at which to cut a text that is longer than
+// maxChars characters. It prefers to cut in front of the outermost element
+// that is not closed within maxChars characters, then outside of elements
+// after whitespace or after a complete element. Otherwise, it cuts within an
+// element after whitespace, in front of a tag that would be cut in half, or
+// after the last complete tag.
+func cutOffset(text string, maxChars int) int {
+	var (
+		limit, space, innerSpace, closed, tag, afterTag, n int
+		inTag                                              bool
+		open                                               []int
+	)
+
+	for i, r := range text {
+		if n == maxChars {
+			limit = i
+			break
+		}
+		n++
+
+		switch {
+		case inTag && r == '>':
+			inTag, afterTag = false, i+1
+
+			switch {
+			case strings.HasPrefix(text[tag:], "</") && len(open) > 0:
+				open = open[:len(open)-1]
+			case opensElement(text[tag:afterTag]):
+				open = append(open, tag)
+			}
+
+			if len(open) == 0 {
+				closed = afterTag
+			}
+		case inTag:
+		case r == '<' && isTagStart(text[i+1:]):
+			inTag, tag = true, i
+		case unicode.IsSpace(r) && len(open) == 0:
+			space = i + utf8.RuneLen(r)
+		case unicode.IsSpace(r):
+			innerSpace = i + utf8.RuneLen(r)
+		}
+	}
+
+	switch {
+	case len(open) > 0 && open[0] > 0:
+		return open[0]
+	case space > 0:
+		return space
+	case closed > 0:
+		return closed
+	case innerSpace > 0:
+		return innerSpace
+	case inTag && tag > 0:
+		return tag
+	case afterTag > 0:
+		return afterTag
+	default:
+		return limit
+	}
+}
+
+// isTagStart reports whether the text following a '<' starts a tag, i.e. a
+// letter, an end tag, a comment, or a declaration.
+func isTagStart(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsLetter(r) || r == '/' || r == '!' || r == '?'
+}
+
+// voidElements are the HTML elements that have no end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// opensElement reports whether the given tag opens an element that is closed
+// by an end tag, i.e. whether it is a start tag that is neither self-closing
+// nor the tag of a void element.
+func opensElement(tag string) bool {
+	r, _ := utf8.DecodeRuneInString(tag[1:])
+	if !unicode.IsLetter(r) || strings.HasSuffix(tag, "/>") {
+		return false
+	}
+
+	name := tag[1:]
+	if end := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != ':'
+	}); end >= 0 {
+		name = name[:end]
+	}
+
+	return !voidElements[strings.ToLower(name)]
+}
+
+// tagEnd returns the offset following the end of the tag that starts at the
+// given offset, or -1 if the tag is not closed.
+func tagEnd(text string, start int) int {
+	for i := start; i < len(text); i++ {
+		if text[i] == '>' {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func isTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…':
+		return true
+	}
+	return isFullWidthTerminator(r)
+}
+
+func isFullWidthTerminator(r rune) bool {
+	switch r {
+	case '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '»', '”', '’', '」', '』', '）':
+		return true
+	}
+	return false
+}
+
+func countNewlines(s string) int {
+	n := 0
+	for _, r := range s {
+		if r == '\n' {
+			n++
+		}
+	}
+	return n
+}
+
+func length(s string) int {
+	return utf8.RuneCountInString(s)
+}
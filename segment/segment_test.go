@@ -0,0 +1,141 @@
+package segment
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		text     string
+		maxChars int
+		want     []string
+	}{
+		{
+			name:     "empty",
+			text:     "",
+			maxChars: 10,
+			want:     nil,
+		},
+		{
+			name:     "short",
+			text:     "Hello World!",
+			maxChars: 100,
+			want:     []string{"Hello World!"},
+		},
+		{
+			name:     "paragraphs",
+			text:     "First paragraph.\n\n  Second paragraph.\n \n",
+			maxChars: 0,
+			want:     []string{"First paragraph.\n\n  ", "Second paragraph.\n \n"},
+		},
+		{
+			name:     "sentences",
+			text:     "One sentence. Another one! And a third? Yes.",
+			maxChars: 30,
+			want:     []string{"One sentence. Another one! ", "And a third? Yes."},
+		},
+		{
+			name:     "quotes",
+			text:     `He said "Stop." Then he left.`,
+			maxChars: 20,
+			want:     []string{`He said "Stop." `, "Then he left."},
+		},
+		{
+			name:     "abbreviations",
+			text:     "Use e.g. this one. Or that one.",
+			maxChars: 20,
+			want:     []string{"Use e.g. this one. ", "Or that one."},
+		},
+		{
+			name:     "decimals",
+			text:     "Pi is 3.14159 roughly. Or so.",
+			maxChars: 25,
+			want:     []string{"Pi is 3.14159 roughly. ", "Or so."},
+		},
+		{
+			name:     "full width",
+			text:     "这是第一句。这是第二句！「第三句？」好。",
+			maxChars: 8,
+			want:     []string{"这是第一句。", "这是第二句！", "「第三句？」好。"},
+		},
+		{
+			name:     "long sentence",
+			text:     "This sentence is way too long to fit.",
+			maxChars: 16,
+			want:     []string{"This sentence ", "is way too long ", "to fit."},
+		},
+		{
+			name:     "long word",
+			text:     "Rindfleischetikettierung",
+			maxChars: 10,
+			want:     []string{"Rindfleisc", "hetikettie", "rung"},
+		},
+		{
+			name:     "markup",
+			text:     `<p class="intro. Not a sentence">Hello. World.</p>`,
+			maxChars: 45,
+			want:     []string{`<p class="intro. Not a sentence">Hello. `, "World.</p>"},
+		},
+		{
+			name:     "long markup",
+			text:     "Hello<span>x</span>",
+			maxChars: 14,
+			want:     []string{"Hello", "<span>x</span>"},
+		},
+		{
+			name:     "element in long sentence",
+			text:     "Say <b>hello big world</b> now",
+			maxChars: 22,
+			want:     []string{"Say ", "<b>hello big world</b>", " now"},
+		},
+		{
+			name:     "void element",
+			text:     "Hello<br>World<span>x</span>",
+			maxChars: 20,
+			want:     []string{"Hello<br>World", "<span>x</span>"},
+		},
+		{
+			name:     "element too long",
+			text:     "Hello<span>x</span>",
+			maxChars: 8,
+			want:     []string{"Hello", "<span>x", "</span>"},
+		},
+		{
+			name:     "comparison",
+			text:     "If a < b. Then b > a.",
+			maxChars: 12,
+			want:     []string{"If a < b. ", "Then b > a."},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Split(tc.text, tc.maxChars)
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Unexpected segments. Got: %q. Want: %q.", got, tc.want)
+			}
+
+			if strings.Join(got, "") != tc.text {
+				t.Fatalf("Segments do not add up to text. Got: %q. Want: %q.", strings.Join(got, ""), tc.text)
+			}
+		})
+	}
+}
+
+func TestSplitMaxChars(t *testing.T) {
+	text := strings.Repeat("Ein kurzer Satz. Ein etwas längerer Satz mit mehr Wörtern!\n\n", 50)
+
+	segments := Split(text, 100)
+	for _, segment := range segments {
+		if n := utf8.RuneCountInString(segment); n > 100 {
+			t.Fatalf("Segment exceeds 100 characters: %d", n)
+		}
+	}
+
+	if strings.Join(segments, "") != text {
+		t.Fatal("Segments do not add up to text.")
+	}
+}
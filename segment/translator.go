@@ -0,0 +1,152 @@
+package segment
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/st3v/translator"
+)
+
+// Translator translates texts of any length through another Translator. It
+// splits each text into segments, translates the segments concurrently, and
+// joins the translations, keeping the whitespace between segments as it is.
+// It implements translator.ContextTranslator, regardless of the wrapped
+// Translator. Languages and language detections are passed on as they are.
+type Translator struct {
+	translator  translator.Translator
+	maxChars    int
+	parallelism int
+}
+
+// NewTranslator returns a Translator that splits texts into segments of at
+// most maxChars characters and passes at most parallelism segments at a time
+// on to the given Translator. Segments are translated one at a time if
+// parallelism is not positive.
+func NewTranslator(t translator.Translator, maxChars, parallelism int) *Translator {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	return &Translator{
+		translator:  t,
+		maxChars:    maxChars,
+		parallelism: parallelism,
+	}
+}
+
+// Languages implements translator.Translator.
+func (t *Translator) Languages() ([]translator.Language, error) {
+	return t.LanguagesContext(context.Background())
+}
+
+// Translate implements translator.Translator.
+func (t *Translator) Translate(text, from, to string) (string, error) {
+	return t.TranslateContext(context.Background(), text, from, to)
+}
+
+// Detect implements translator.Translator.
+func (t *Translator) Detect(text string) (string, error) {
+	return t.DetectContext(context.Background(), text)
+}
+
+// LanguagesContext implements translator.ContextTranslator.
+func (t *Translator) LanguagesContext(ctx context.Context) ([]translator.Language, error) {
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		return ct.LanguagesContext(ctx)
+	}
+	return t.translator.Languages()
+}
+
+// DetectContext implements translator.ContextTranslator.
+func (t *Translator) DetectContext(ctx context.Context, text string) (string, error) {
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		return ct.DetectContext(ctx, text)
+	}
+	return t.translator.Detect(text)
+}
+
+// TranslateContext implements translator.ContextTranslator. Segments that
+// consist of whitespace only are not translated. The first error cancels
+// all outstanding segments and is returned.
+func (t *Translator) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	segments := Split(text, t.maxChars)
+	translations := make([]string, len(segments))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		once      sync.Once
+		firstErr  error
+		semaphore = make(chan struct{}, t.parallelism)
+	)
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i, segment := range segments {
+		leading, content, trailing := trimSpace(segment)
+		if content == "" {
+			translations[i] = segment
+			continue
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if err := ctx.Err(); err != nil {
+			fail(err)
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, leading, content, trailing string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			translation, err := t.translate(ctx, content, from, to)
+			if err != nil {
+				fail(err)
+				return
+			}
+
+			translations[i] = leading + translation + trailing
+		}(i, leading, content, trailing)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+
+	return strings.Join(translations, ""), nil
+}
+
+func (t *Translator) translate(ctx context.Context, text, from, to string) (string, error) {
+	if ct, ok := t.translator.(translator.ContextTranslator); ok {
+		return ct.TranslateContext(ctx, text, from, to)
+	}
+	return t.translator.Translate(text, from, to)
+}
+
+// trimSpace splits s into its leading whitespace, its content, and its
+// trailing whitespace.
+func trimSpace(s string) (string, string, string) {
+	content := strings.TrimLeftFunc(s, unicode.IsSpace)
+	leading := s[:len(s)-len(content)]
+
+	content = strings.TrimRightFunc(content, unicode.IsSpace)
+	trailing := s[len(leading)+len(content):]
+
+	return leading, content, trailing
+}
@@ -0,0 +1,124 @@
+package segment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/st3v/translator"
+)
+
+type mockTranslator struct {
+	mutex   sync.Mutex
+	texts   []string
+	running int
+	peak    int
+	delay   time.Duration
+	fail    string
+}
+
+func (m *mockTranslator) Languages() ([]translator.Language, error) {
+	return nil, nil
+}
+
+func (m *mockTranslator) Detect(text string) (string, error) {
+	return "de", nil
+}
+
+func (m *mockTranslator) Translate(text, from, to string) (string, error) {
+	m.mutex.Lock()
+	m.texts = append(m.texts, text)
+	m.running++
+	if m.running > m.peak {
+		m.peak = m.running
+	}
+	m.mutex.Unlock()
+
+	time.Sleep(m.delay)
+
+	m.mutex.Lock()
+	m.running--
+	m.mutex.Unlock()
+
+	if text == m.fail {
+		return "", errors.New("fake-error")
+	}
+
+	return strings.ToUpper(text), nil
+}
+
+func TestTranslatorTranslate(t *testing.T) {
+	mock := &mockTranslator{delay: 10 * time.Millisecond}
+	tr := NewTranslator(mock, 20, 2)
+
+	text := "\n  First sentence. Second sentence.\n\n\tThird sentence!  \n\n"
+
+	translation, err := tr.Translate(text, "en", "de")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	want := "\n  FIRST SENTENCE. SECOND SENTENCE.\n\n\tTHIRD SENTENCE!  \n\n"
+	if translation != want {
+		t.Fatalf("Unexpected translation. Got: %q. Want: %q.", translation, want)
+	}
+
+	if len(mock.texts) != 3 {
+		t.Fatalf("Expected 3 segments to be translated. Got: %q", mock.texts)
+	}
+
+	for _, text := range mock.texts {
+		if strings.TrimSpace(text) != text {
+			t.Fatalf("Segments should be translated without surrounding whitespace. Got: %q", text)
+		}
+	}
+
+	if mock.peak != 2 {
+		t.Fatalf("Unexpected number of concurrent translations. Got: %d. Want: 2.", mock.peak)
+	}
+}
+
+func TestTranslatorTranslateError(t *testing.T) {
+	mock := &mockTranslator{fail: "Second."}
+	tr := NewTranslator(mock, 10, 1)
+
+	_, err := tr.Translate("First. Second. Third.", "en", "de")
+	if err == nil || err.Error() != "fake-error" {
+		t.Fatalf("Expected fake-error. Got: %v", err)
+	}
+
+	if len(mock.texts) != 2 {
+		t.Fatalf("Translation should stop after the first error. Translated: %q", mock.texts)
+	}
+}
+
+func TestTranslatorTranslateCanceled(t *testing.T) {
+	mock := &mockTranslator{}
+	tr := NewTranslator(mock, 10, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := tr.TranslateContext(ctx, "First. Second. Third.", "en", "de")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled. Got: %v", err)
+	}
+}
+
+func TestTranslatorPassThrough(t *testing.T) {
+	tr := NewTranslator(&mockTranslator{}, 10, 1)
+
+	language, err := tr.Detect("hallo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if language != "de" {
+		t.Fatalf("Unexpected language. Got: %s. Want: de.", language)
+	}
+
+	var _ translator.ContextTranslator = tr
+}
@@ -1,10 +1,47 @@
 package translator
 
+import "context"
+
 // The Language struct represents a given language by its
 // name and code.
 type Language struct {
+	// Code is the API-specific code of the language, e.g. zh-CHT.
 	Code string
+
+	// Name is the name of the language in English or, if obtained by means
+	// of LocalizedTranslator, in the requested display locale.
 	Name string
+
+	// Tag is the canonical BCP 47 tag of the language, e.g. zh-Hant,
+	// which is understood by all translators regardless of their API.
+	Tag string
+}
+
+// The Detection struct represents a candidate language for a given text.
+type Detection struct {
+	// Language is the API-specific code of the candidate language.
+	Language string
+
+	// Confidence ranges from 0 to 1, higher values indicating a more
+	// likely candidate.
+	Confidence float64
+
+	// Reliable indicates whether the API considers the candidate a
+	// reliable detection.
+	Reliable bool
+}
+
+// The Script struct represents a script in which a language can be written.
+type Script struct {
+	// Code is the ISO 15924 code of the script, e.g. Jpan or Latn.
+	Code string
+
+	// Name is the name of the script in English.
+	Name string
+
+	// ToScripts are the codes of the scripts that texts in this script can
+	// be transliterated to.
+	ToScripts []string
 }
 
 // The Translator interface represents a translation service.
@@ -15,10 +52,155 @@ type Translator interface {
 
 	// Translate takes a string in a given language and returns its translation
 	// to another language. Source and destination languages are specified by their
-	// corresponding language codes.
+	// corresponding language codes or canonical BCP 47 tags.
 	Translate(text, from, to string) (string, error)
 
 	// Detect identifies the language of the given text and returns the
 	// corresponding language code.
 	Detect(text string) (string, error)
 }
+
+// The ContextTranslator interface represents a translation service whose
+// requests can be cancelled or bound to a deadline by means of a context.
+// The translators returned by the google and microsoft packages implement
+// this interface.
+type ContextTranslator interface {
+	Translator
+
+	// LanguagesContext is like Languages but aborts the underlying
+	// API calls once the given context is done.
+	LanguagesContext(ctx context.Context) ([]Language, error)
+
+	// TranslateContext is like Translate but aborts the underlying
+	// API calls once the given context is done.
+	TranslateContext(ctx context.Context, text, from, to string) (string, error)
+
+	// DetectContext is like Detect but aborts the underlying
+	// API calls once the given context is done.
+	DetectContext(ctx context.Context, text string) (string, error)
+}
+
+// The BatchTranslator interface represents a translation service that is
+// able to translate multiple texts with as few API requests as possible.
+// The translators returned by the google and microsoft packages implement
+// this interface.
+type BatchTranslator interface {
+	Translator
+
+	// TranslateBatch translates each of the given texts from one language to
+	// another. The returned slice holds the translations in the same order as
+	// the original texts.
+	TranslateBatch(texts []string, from, to string) ([]string, error)
+
+	// TranslateBatchContext is like TranslateBatch but aborts the underlying
+	// API calls once the given context is done.
+	TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error)
+}
+
+// The OptionsTranslator interface represents a translation service that
+// accepts options beyond the source and target language. The translators
+// returned by the google, google/advanced, microsoft, deepl, libretranslate,
+// and aws packages implement this interface, the translators that wrap other
+// translators do not. Options that a translation service does not support
+// result in an *OptionError rather than being ignored.
+type OptionsTranslator interface {
+	Translator
+
+	// TranslateWithOptions translates the text of the given request according
+	// to its options and aborts the underlying API calls once the given
+	// context is done.
+	TranslateWithOptions(ctx context.Context, req TranslateRequest) (TranslateResult, error)
+}
+
+// The LocalizedTranslator interface represents a translation service that is
+// able to name its supported languages in languages other than English.
+// The translators returned by the google and microsoft packages implement
+// this interface.
+type LocalizedTranslator interface {
+	Translator
+
+	// LanguagesIn is like Languages but returns the names of the languages
+	// in the given display locale, e.g. Deutsch rather than German for de.
+	LanguagesIn(displayLocale string) ([]Language, error)
+
+	// LanguagesInContext is like LanguagesIn but aborts the underlying
+	// API calls once the given context is done.
+	LanguagesInContext(ctx context.Context, displayLocale string) ([]Language, error)
+}
+
+// The DetailedDetector interface represents a translation service that
+// scores the candidate languages it detects. The translators returned by
+// google.NewTranslator and microsoft.NewTranslatorV3 implement this interface.
+type DetailedDetector interface {
+	Translator
+
+	// DetectDetailed identifies the language of the given text and returns
+	// the candidate languages ranked by confidence, the most likely candidate
+	// first. ErrNoDetection is returned if the API detected no language.
+	DetectDetailed(text string) ([]Detection, error)
+
+	// DetectDetailedContext is like DetectDetailed but aborts the underlying
+	// API calls once the given context is done.
+	DetectDetailedContext(ctx context.Context, text string) ([]Detection, error)
+}
+
+// The BatchDetector interface represents a translation service that is able
+// to detect the languages of multiple texts with as few API requests as
+// possible. The translators returned by the google and microsoft packages
+// implement this interface.
+type BatchDetector interface {
+	Translator
+
+	// DetectBatch identifies the language of each of the given texts. The
+	// returned slice holds the most likely candidate for each text in the
+	// same order as the texts. Its Language is empty if the API detected no
+	// language for the text. APIs that do not score their detections report
+	// a confidence of zero.
+	DetectBatch(texts []string) ([]Detection, error)
+
+	// DetectBatchContext is like DetectBatch but aborts the underlying
+	// API calls once the given context is done.
+	DetectBatchContext(ctx context.Context, texts []string) ([]Detection, error)
+}
+
+// The LanguageRefresher interface represents a translation service that
+// caches the languages it supports. The translators returned by the google,
+// google/advanced, microsoft, deepl, libretranslate, and aws packages
+// implement this interface, the translators that wrap other translators do
+// not.
+type LanguageRefresher interface {
+	Translator
+
+	// RefreshLanguages fetches the supported languages of all display locales
+	// that have been requested so far, regardless of their age. Previously
+	// cached languages are kept if the refresh fails.
+	RefreshLanguages(ctx context.Context) error
+}
+
+// The Transliterator interface represents a translation service that is able
+// to convert texts from one script to another without translating them, e.g.
+// to romanize Japanese names. The translator returned by
+// microsoft.NewTranslatorV3 implements this interface.
+type Transliterator interface {
+	Translator
+
+	// Transliterate converts text in the given language from one script to
+	// another, e.g. from Jpan to Latn. The language is specified by its
+	// language code or canonical BCP 47 tag, the scripts by their ISO 15924
+	// codes.
+	Transliterate(text, language, fromScript, toScript string) (string, error)
+
+	// TransliterateContext is like Transliterate but aborts the underlying
+	// API calls once the given context is done.
+	TransliterateContext(ctx context.Context, text, language, fromScript, toScript string) (string, error)
+
+	// Scripts returns the scripts of the given language that texts can be
+	// transliterated from, each with the scripts it can be transliterated
+	// to. ErrUnsupportedLanguage is returned if the language cannot be
+	// transliterated.
+	Scripts(language string) ([]Script, error)
+
+	// ScriptsContext is like Scripts but aborts the underlying API calls
+	// once the given context is done.
+	ScriptsContext(ctx context.Context, language string) ([]Script, error)
+}
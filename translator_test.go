@@ -1,6 +1,9 @@
 package translator
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 // Make sure nobody breaks the interface.
 func TestTranslatorInterface(t *testing.T) {
@@ -8,6 +11,18 @@ func TestTranslatorInterface(t *testing.T) {
 	translator.Translate("", "", "")
 }
 
+// Make sure nobody breaks the context-aware interface.
+func TestContextTranslatorInterface(t *testing.T) {
+	var translator ContextTranslator = &testTranslator{}
+	translator.TranslateContext(context.Background(), "", "", "")
+}
+
+// Make sure nobody breaks the batch interface.
+func TestBatchTranslatorInterface(t *testing.T) {
+	var translator BatchTranslator = &testTranslator{}
+	translator.TranslateBatch(nil, "", "")
+}
+
 type testTranslator struct{}
 
 func (t *testTranslator) Languages() ([]Language, error) {
@@ -21,3 +36,23 @@ func (t *testTranslator) Translate(text, from, to string) (string, error) {
 func (t *testTranslator) Detect(text string) (string, error) {
 	return "", nil
 }
+
+func (t *testTranslator) LanguagesContext(ctx context.Context) ([]Language, error) {
+	return nil, nil
+}
+
+func (t *testTranslator) TranslateContext(ctx context.Context, text, from, to string) (string, error) {
+	return "", nil
+}
+
+func (t *testTranslator) DetectContext(ctx context.Context, text string) (string, error) {
+	return "", nil
+}
+
+func (t *testTranslator) TranslateBatch(texts []string, from, to string) ([]string, error) {
+	return nil, nil
+}
+
+func (t *testTranslator) TranslateBatchContext(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	return nil, nil
+}